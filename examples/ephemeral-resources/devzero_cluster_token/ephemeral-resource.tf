@@ -0,0 +1,16 @@
+variable "rotate_token" {
+  description = "Set to true for the run that installs the Devzero operator or rotates its token"
+  type        = bool
+  default     = false
+}
+
+resource "devzero_cluster" "cluster" {
+  name        = "terraform-example"
+  store_token = false
+}
+
+# Issue a new token, without storing it in the state, only when rotate_token is set
+ephemeral "devzero_cluster_token" "cluster" {
+  cluster_id = devzero_cluster.cluster.id
+  rotate     = var.rotate_token
+}
@@ -9,4 +9,10 @@ terraform {
 provider "devzero" {
   team_id = "<YOUR_TEAM_ID>"
   token   = "<YOUR_TOKEN>"
-}
\ No newline at end of file
+}
+
+# Read url, team_id and token from the "staging" profile in ~/.devzero/credentials
+provider "devzero" {
+  alias   = "staging"
+  profile = "staging"
+}
@@ -1 +1,9 @@
-terraform import devzero_cluster.cluster <cluster_id>
\ No newline at end of file
+terraform import devzero_cluster.cluster <cluster_id>
+
+# Import a cluster that belongs to a team other than the provider team_id
+terraform import devzero_cluster.cluster <team_id>/<cluster_id>
+
+# Import a cluster by name. The import fails if no cluster of the team, or more
+# than one, has that name.
+terraform import devzero_cluster.cluster name:<cluster_name>
+terraform import devzero_cluster.cluster <team_id>/name:<cluster_name>
@@ -1,3 +1,4 @@
 resource "devzero_cluster" "cluster" {
   name = "terraform-example"
+  tags = ["env:dev"]
 }
\ No newline at end of file
@@ -0,0 +1,8 @@
+resource "devzero_cluster" "cluster" {
+  name = "terraform-example"
+}
+
+# Disable every optimization of the cluster, e.g. during an incident
+resource "devzero_cluster_optimizations_disabled" "cluster" {
+  cluster_id = devzero_cluster.cluster.id
+}
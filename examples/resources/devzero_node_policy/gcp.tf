@@ -0,0 +1,151 @@
+# Complete GCP Node Policy Example
+#
+# This example demonstrates a comprehensive GCP node policy with:
+# - GCP-specific configuration
+# - Instance selection for GCE machine families
+# - Cost optimization with spot instances
+# - Boot disk and network tag configuration
+
+resource "devzero_node_policy" "gcp_production" {
+  name            = "gcp-production"
+  description     = "Production node policy for GKE clusters"
+  node_pool_name  = "gcp-production-pool"
+  node_class_name = "gcp-production-class"
+  weight          = 15
+
+  # GCE machine families
+  instance_families = {
+    match_expressions = [{
+      key      = "instanceFamilies"
+      operator = "In"
+      values   = ["n2", "n2d", "c3"]
+    }]
+  }
+
+  # Architecture
+  architectures = {
+    match_expressions = [{
+      key      = "architectures"
+      operator = "In"
+      values   = ["amd64"]
+    }]
+  }
+
+  # Capacity types - use spot for cost savings
+  capacity_types = {
+    match_expressions = [{
+      key      = "capacityTypes"
+      operator = "In"
+      values   = ["spot", "on-demand"]
+    }]
+  }
+
+  # Operating system
+  operating_systems = {
+    match_expressions = [{
+      key      = "operatingSystems"
+      operator = "In"
+      values   = ["linux"]
+    }]
+  }
+
+  # Labels for node identification
+  labels = {
+    "dedicated"     = "karpenter"
+    "cloud"         = "gcp"
+    "workload-type" = "general"
+  }
+
+  # Taints for workload isolation
+  taints = [
+    {
+      key    = "karpenter"
+      value  = "true"
+      effect = "NoSchedule"
+    }
+  ]
+
+  disruption = {
+    consolidate_after    = "10m"
+    consolidation_policy = "WhenEmptyOrUnderutilized"
+    expire_after         = "168h" # 7 days
+
+    budgets = [
+      {
+        reasons = ["Empty", "Drifted", "Underutilized"]
+        nodes   = "10%"
+      }
+    ]
+  }
+
+  # Resource limits
+  limits = {
+    cpu    = "500"
+    memory = "1000Gi"
+  }
+
+  # GCP-specific configuration
+  gcp = {
+    # Service account used by the nodes
+    service_account = "karpenter-nodes@my-project.iam.gserviceaccount.com"
+
+    # Image selection
+    image_family = "ContainerOptimizedOS"
+    image_selector_terms = [
+      {
+        alias = "ContainerOptimizedOS@latest"
+      }
+    ]
+
+    # Kubelet overrides
+    kubelet_configuration = {
+      max_pods = 110
+      system_reserved = {
+        cpu    = "100m"
+        memory = "256Mi"
+      }
+      eviction_hard = {
+        "memory.available" = "5%"
+      }
+    }
+
+    # Network tags used by firewall rules
+    network_tags = ["gke-nodes", "allow-health-checks"]
+
+    # Instance metadata
+    metadata = {
+      "block-project-ssh-keys" = "true"
+    }
+
+    # Boot disk
+    disks = [
+      {
+        size_gib = 100
+        category = "pd-balanced"
+        boot     = true
+      }
+    ]
+
+    # GCP labels
+    labels = {
+      "environment" = "production"
+      "managed-by"  = "karpenter"
+      "team"        = "platform"
+    }
+  }
+}
+
+# Complete example with targets
+resource "devzero_cluster" "gcp_us_central" {
+  name = "gke-us-central-prod"
+}
+
+resource "devzero_node_policy_target" "gcp_production_target" {
+  name        = "gcp-production-clusters"
+  description = "Apply production node policy to GKE clusters"
+  policy_id   = devzero_node_policy.gcp_production.id
+  enabled     = true
+  cluster_ids = [
+    devzero_cluster.gcp_us_central.id,
+  ]
+}
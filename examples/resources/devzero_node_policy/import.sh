@@ -5,3 +5,16 @@ terraform import devzero_node_policy.example "policy-id-here"
 
 # Example with actual ID format
 terraform import devzero_node_policy.aws_basic "257a5739-b716-42c7-9bc4-1823277f3e5f"
+
+# Import a policy that belongs to a team other than the provider team_id
+terraform import devzero_node_policy.example "team-id-here/policy-id-here"
+
+# Import a policy by name. The import fails if no policy of the team, or more
+# than one, has that name.
+terraform import devzero_node_policy.example "name:policy-name-here"
+terraform import devzero_node_policy.example "team-id-here/name:policy-name-here"
+
+# Clean up a policy orphaned by an older provider version (whose destroy only
+# removed it from state): import it, then destroy it
+terraform import devzero_node_policy.orphaned "policy-id-here"
+terraform destroy -target=devzero_node_policy.orphaned
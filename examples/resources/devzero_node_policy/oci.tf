@@ -0,0 +1,133 @@
+# Complete OCI Node Policy Example
+#
+# This example demonstrates an OCI node policy for OKE clusters with:
+# - OCI-specific configuration
+# - Image, subnet and network security group selection
+# - Boot volume and launch options
+# - Defined and free-form tags
+
+resource "devzero_node_policy" "oci_production" {
+  name            = "oci-production"
+  description     = "Production node policy for OKE clusters"
+  node_pool_name  = "oci-production-pool"
+  node_class_name = "oci-production-class"
+  weight          = 15
+
+  # Flexible shapes
+  instance_families = {
+    match_expressions = [{
+      key      = "instanceFamilies"
+      operator = "In"
+      values   = ["VM.Standard.E4", "VM.Standard.E5"]
+    }]
+  }
+
+  # Architecture
+  architectures = {
+    match_expressions = [{
+      key      = "architectures"
+      operator = "In"
+      values   = ["amd64"]
+    }]
+  }
+
+  # Capacity types - use preemptible capacity for cost savings
+  capacity_types = {
+    match_expressions = [{
+      key      = "capacityTypes"
+      operator = "In"
+      values   = ["spot", "on-demand"]
+    }]
+  }
+
+  # Labels for node identification
+  labels = {
+    "dedicated" = "karpenter"
+    "cloud"     = "oci"
+  }
+
+  disruption = {
+    consolidate_after    = "10m"
+    consolidation_policy = "WhenEmptyOrUnderutilized"
+    expire_after         = "168h" # 7 days
+
+    budgets = [
+      {
+        reasons = ["Empty", "Drifted", "Underutilized"]
+        nodes   = "10%"
+      }
+    ]
+  }
+
+  # Resource limits
+  limits = {
+    cpu    = "500"
+    memory = "1000Gi"
+  }
+
+  # OCI-specific configuration
+  oci = {
+    vcn_id       = "ocid1.vcn.oc1.iad.exampleuniqueid"
+    image_family = "OracleLinux"
+
+    image_selector = [
+      {
+        name           = "Oracle-Linux-8.10-2025.01.31-0-OKE-1.31.1"
+        compartment_id = "ocid1.compartment.oc1..exampleuniqueid"
+      }
+    ]
+
+    subnet_selector = [
+      {
+        name = "oke-workers"
+      }
+    ]
+
+    security_group_selector = [
+      {
+        id = "ocid1.networksecuritygroup.oc1.iad.exampleuniqueid"
+      }
+    ]
+
+    # Boot volume
+    boot_config = {
+      boot_volume_size_in_gbs = 100
+      boot_volume_vpus_per_gb = 10
+    }
+
+    launch_options = {
+      firmware     = "UEFI_64"
+      network_type = "PARAVIRTUALIZED"
+    }
+
+    # Additional block volumes
+    block_devices = [
+      {
+        size_in_gbs = 200
+        vpus_per_gb = 20
+      }
+    ]
+
+    # Oracle Cloud Agent plugins
+    agent_list = ["Bastion", "Compute Instance Monitoring"]
+
+    free_form_tags = {
+      "Environment" = "production"
+      "ManagedBy"   = "Karpenter"
+    }
+  }
+}
+
+resource "devzero_cluster" "oke_us_ashburn" {
+  name = "oke-us-ashburn-prod"
+}
+
+resource "devzero_node_policy_target" "oci_production_target" {
+  name        = "oci-production-clusters"
+  description = "Apply production node policy to OKE clusters"
+  policy_id   = devzero_node_policy.oci_production.id
+  enabled     = true
+  cluster_ids = [
+    devzero_cluster.oke_us_ashburn.id,
+  ]
+}
@@ -91,6 +91,20 @@ resource "devzero_node_policy" "aws_comprehensive" {
     }
   ]
 
+  # Removed by the CNI agent once the node is ready
+  startup_taints = [
+    {
+      key    = "node.cilium.io/agent-not-ready"
+      value  = "true"
+      effect = "NoExecute"
+    }
+  ]
+
+  # Stop launching nodes in zones under an ARC zonal shift
+  zonal_shift = {
+    respect_zonal_shift = true
+  }
+
   # Disruption policy for cost optimization
   disruption = {
     consolidate_after    = "15m"
@@ -5,3 +5,6 @@ terraform import devzero_node_policy_target.example "target-id-here"
 
 # Example with actual ID format
 terraform import devzero_node_policy_target.comprehensive "c84ccd96-d3f6-439d-9976-360577123fe0"
+
+# Import a target that belongs to a team other than the provider team_id
+terraform import devzero_node_policy_target.example "team-id-here/target-id-here"
@@ -1 +1,9 @@
-terraform import devzero_workload_policy.workload_policy <workload_policy_id>
\ No newline at end of file
+terraform import devzero_workload_policy.workload_policy <workload_policy_id>
+
+# Import a workload policy that belongs to a team other than the provider team_id
+terraform import devzero_workload_policy.workload_policy <team_id>/<workload_policy_id>
+
+# Import a workload policy by name. The import fails if no policy of the team,
+# or more than one, has that name.
+terraform import devzero_workload_policy.workload_policy name:<workload_policy_name>
+terraform import devzero_workload_policy.workload_policy <team_id>/name:<workload_policy_name>
@@ -1 +1,4 @@
-terraform import devzero_workload_policy_target.workload_policy_target <workload_policy_target_id>
\ No newline at end of file
+terraform import devzero_workload_policy_target.workload_policy_target <workload_policy_target_id>
+
+# Import a workload policy target that belongs to a team other than the provider team_id
+terraform import devzero_workload_policy_target.workload_policy_target <team_id>/<workload_policy_target_id>
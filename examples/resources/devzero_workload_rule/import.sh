@@ -0,0 +1,8 @@
+terraform import devzero_workload_rule.workload_rule <workload_rule_id>
+
+# Import a workload rule that belongs to a team other than the provider team_id
+terraform import devzero_workload_rule.workload_rule <team_id>/<workload_rule_id>
+
+# Import the workload rule of a workload, e.g. <cluster_id>/production/Deployment/my-api
+terraform import devzero_workload_rule.workload_rule <cluster_id>/<namespace>/<kind>/<name>
+terraform import devzero_workload_rule.workload_rule <team_id>/<cluster_id>/<namespace>/<kind>/<name>
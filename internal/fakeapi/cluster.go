@@ -0,0 +1,255 @@
+package fakeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
+)
+
+// clusterMutationService implements the ClusterMutationService RPCs used by the provider.
+type clusterMutationService struct {
+	*Server
+}
+
+var _ apiv1connect.ClusterMutationServiceHandler = &clusterMutationService{}
+
+func (s *clusterMutationService) CreateCluster(ctx context.Context, req *connect.Request[apiv1.CreateClusterRequest]) (*connect.Response[apiv1.CreateClusterResponse], error) {
+	if req.Msg.ClusterName == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("cluster_name is required"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().Unix()
+	cluster := &apiv1.Cluster{
+		Id:          newID(),
+		TeamId:      req.Msg.TeamId,
+		Name:        req.Msg.ClusterName,
+		CustomName:  req.Msg.ClusterName,
+		DisplayName: req.Msg.ClusterName,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.clusters[cluster.Id] = cluster
+	s.clusterTokens[cluster.Id] = newID()
+
+	return connect.NewResponse(&apiv1.CreateClusterResponse{
+		Cluster: clone(cluster),
+		Token:   s.clusterTokens[cluster.Id],
+	}), nil
+}
+
+func (s *clusterMutationService) UpdateCluster(ctx context.Context, req *connect.Request[apiv1.UpdateClusterRequest]) (*connect.Response[apiv1.UpdateClusterResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, err := s.lookupCluster(req.Msg.TeamId, req.Msg.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.CustomName = req.Msg.ClusterName
+	cluster.DisplayName = req.Msg.ClusterName
+	cluster.HasBeenUpdated = true
+	cluster.UpdatedAt = time.Now().Unix()
+
+	return connect.NewResponse(&apiv1.UpdateClusterResponse{Cluster: clone(cluster)}), nil
+}
+
+func (s *clusterMutationService) ResetClusterToken(ctx context.Context, req *connect.Request[apiv1.ResetClusterTokenRequest]) (*connect.Response[apiv1.ResetClusterTokenResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookupCluster(req.Msg.TeamId, req.Msg.ClusterId); err != nil {
+		return nil, err
+	}
+
+	s.clusterTokens[req.Msg.ClusterId] = newID()
+
+	return connect.NewResponse(&apiv1.ResetClusterTokenResponse{Token: s.clusterTokens[req.Msg.ClusterId]}), nil
+}
+
+func (s *clusterMutationService) DeleteCluster(ctx context.Context, req *connect.Request[apiv1.DeleteClusterRequest]) (*connect.Response[apiv1.DeleteClusterResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookupCluster(req.Msg.TeamId, req.Msg.ClusterId); err != nil {
+		return nil, err
+	}
+
+	delete(s.clusters, req.Msg.ClusterId)
+	delete(s.clusterTokens, req.Msg.ClusterId)
+	delete(s.clusterPolls, req.Msg.ClusterId)
+	delete(s.clusterK8SProviders, req.Msg.ClusterId)
+
+	return connect.NewResponse(&apiv1.DeleteClusterResponse{}), nil
+}
+
+// k8sService implements the K8SService RPCs used by the provider.
+type k8sService struct {
+	*Server
+	apiv1connect.UnimplementedK8SServiceHandler
+}
+
+func (s *k8sService) GetCluster(ctx context.Context, req *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, err := s.lookupCluster(req.Msg.TeamId, req.Msg.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.clusterPolls[cluster.Id]++
+	if s.connectAfter > 0 && s.clusterPolls[cluster.Id] >= s.connectAfter && cluster.DakrOpInfo == nil {
+		connected := false
+		cluster.IsDisconnected = &connected
+		cluster.DakrOpInfo = &apiv1.OperatorInfo{Version: "v0.0.0-fake"}
+	}
+
+	return connect.NewResponse(&apiv1.GetClusterResponse{Cluster: clone(cluster)}), nil
+}
+
+func (s *k8sService) AddClusterTags(ctx context.Context, req *connect.Request[apiv1.AddClusterTagsRequest]) (*connect.Response[apiv1.AddClusterTagsResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, err := s.lookupCluster(req.Msg.TeamId, req.Msg.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.Tags = append(cluster.Tags, req.Msg.Tags...)
+	slices.Sort(cluster.Tags)
+	cluster.Tags = slices.Compact(cluster.Tags)
+
+	return connect.NewResponse(&apiv1.AddClusterTagsResponse{Cluster: clone(cluster)}), nil
+}
+
+func (s *k8sService) RemoveClusterTags(ctx context.Context, req *connect.Request[apiv1.RemoveClusterTagsRequest]) (*connect.Response[apiv1.RemoveClusterTagsResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, err := s.lookupCluster(req.Msg.TeamId, req.Msg.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.Tags = slices.DeleteFunc(cluster.Tags, func(tag string) bool {
+		return slices.Contains(req.Msg.Tags, tag)
+	})
+
+	return connect.NewResponse(&apiv1.RemoveClusterTagsResponse{Cluster: clone(cluster)}), nil
+}
+
+func (s *k8sService) ListTags(ctx context.Context, req *connect.Request[apiv1.ListTagsRequest]) (*connect.Response[apiv1.ListTagsResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clusterIDs := make(map[string][]string)
+	for _, cluster := range s.clusters {
+		if cluster.TeamId != req.Msg.TeamId {
+			continue
+		}
+		for _, tag := range cluster.Tags {
+			clusterIDs[tag] = append(clusterIDs[tag], cluster.Id)
+		}
+	}
+
+	tags := make([]*apiv1.TagSummary, 0, len(clusterIDs))
+	for tag, ids := range clusterIDs {
+		slices.Sort(ids)
+		tags = append(tags, &apiv1.TagSummary{Tag: tag, ClusterIds: ids})
+	}
+	slices.SortFunc(tags, func(a, b *apiv1.TagSummary) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+
+	return connect.NewResponse(&apiv1.ListTagsResponse{Tags: tags}), nil
+}
+
+// clusterService implements the ClusterService RPCs used by the provider.
+type clusterService struct {
+	*Server
+	apiv1connect.UnimplementedClusterServiceHandler
+}
+
+func (s *clusterService) GetClusterIDByName(ctx context.Context, req *connect.Request[apiv1.GetClusterIDByNameRequest]) (*connect.Response[apiv1.GetClusterIDByNameResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cluster := range s.clusters {
+		if cluster.TeamId == req.Msg.TeamId && (cluster.CustomName == req.Msg.Name || cluster.Name == req.Msg.Name) {
+			return connect.NewResponse(&apiv1.GetClusterIDByNameResponse{Id: cluster.Id}), nil
+		}
+	}
+
+	return connect.NewResponse(&apiv1.GetClusterIDByNameResponse{}), nil
+}
+
+func (s *clusterService) GetClustersBasicInfo(ctx context.Context, req *connect.Request[apiv1.GetClustersBasicInfoRequest]) (*connect.Response[apiv1.GetClustersBasicInfoResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var clusters []*apiv1.Cluster
+	for _, cluster := range s.clusters {
+		if cluster.TeamId == req.Msg.TeamId {
+			clusters = append(clusters, clone(cluster))
+		}
+	}
+
+	return connect.NewResponse(&apiv1.GetClustersBasicInfoResponse{Clusters: clusters}), nil
+}
+
+func (s *clusterService) CreateClusterToken(ctx context.Context, req *connect.Request[apiv1.CreateClusterTokenRequest]) (*connect.Response[apiv1.CreateClusterTokenResponse], error) {
+	if req.Msg.ClusterName == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("cluster_name is required"))
+	}
+	if !slices.Contains([]string{"aws", "azure", "gcp", "oci", "other"}, req.Msg.K8SProvider) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid k8s_provider %q", req.Msg.K8SProvider))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokenTeam == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("API token is not bound to a team"))
+	}
+
+	now := time.Now().Unix()
+	cluster := &apiv1.Cluster{
+		Id:          newID(),
+		TeamId:      s.tokenTeam,
+		Name:        req.Msg.ClusterName,
+		CustomName:  req.Msg.ClusterName,
+		DisplayName: req.Msg.ClusterName,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.clusters[cluster.Id] = cluster
+	s.clusterTokens[cluster.Id] = newID()
+	s.clusterK8SProviders[cluster.Id] = req.Msg.K8SProvider
+
+	return connect.NewResponse(&apiv1.CreateClusterTokenResponse{
+		Token:     s.clusterTokens[cluster.Id],
+		ClusterId: cluster.Id,
+	}), nil
+}
+
+// lookupCluster returns the cluster owned by the team. Callers must hold s.mu.
+func (s *Server) lookupCluster(teamID, clusterID string) (*apiv1.Cluster, error) {
+	cluster, ok := s.clusters[clusterID]
+	if !ok || cluster.TeamId != teamID {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("cluster %q not found", clusterID))
+	}
+	return cluster, nil
+}
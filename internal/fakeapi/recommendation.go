@@ -0,0 +1,506 @@
+package fakeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
+)
+
+// recommendationService implements the K8SRecommendationService RPCs used by the provider.
+type recommendationService struct {
+	*Server
+	apiv1connect.UnimplementedK8SRecommendationServiceHandler
+}
+
+// Workload policies
+
+func (s *recommendationService) CreateWorkloadRecommendationPolicy(ctx context.Context, req *connect.Request[apiv1.CreateWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.CreateWorkloadRecommendationPolicyResponse], error) {
+	if req.Msg.Policy == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("policy is required"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy := clone(req.Msg.Policy)
+	policy.PolicyId = newID()
+	policy.TeamId = req.Msg.TeamId
+	s.workloadPolicies[policy.PolicyId] = policy
+
+	return connect.NewResponse(&apiv1.CreateWorkloadRecommendationPolicyResponse{Policy: clone(policy)}), nil
+}
+
+func (s *recommendationService) GetWorkloadRecommendationPolicy(ctx context.Context, req *connect.Request[apiv1.GetWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.GetWorkloadRecommendationPolicyResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, ok := s.workloadPolicies[req.Msg.PolicyId]
+	if !ok || policy.TeamId != req.Msg.TeamId {
+		return nil, notFound("workload policy", req.Msg.PolicyId)
+	}
+
+	return connect.NewResponse(&apiv1.GetWorkloadRecommendationPolicyResponse{Policy: clone(policy)}), nil
+}
+
+func (s *recommendationService) ListWorkloadRecommendationPolicies(ctx context.Context, req *connect.Request[apiv1.ListWorkloadRecommendationPoliciesRequest]) (*connect.Response[apiv1.ListWorkloadRecommendationPoliciesResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var policies []*apiv1.WorkloadRecommendationPolicy
+	for _, policy := range s.workloadPolicies {
+		if policy.TeamId == req.Msg.TeamId {
+			policies = append(policies, clone(policy))
+		}
+	}
+
+	return connect.NewResponse(&apiv1.ListWorkloadRecommendationPoliciesResponse{Policies: policies}), nil
+}
+
+func (s *recommendationService) UpdateWorkloadRecommendationPolicy(ctx context.Context, req *connect.Request[apiv1.UpdateWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.UpdateWorkloadRecommendationPolicyResponse], error) {
+	if req.Msg.Policy == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("policy is required"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.workloadPolicies[req.Msg.Policy.PolicyId]
+	if !ok || existing.TeamId != req.Msg.TeamId {
+		return nil, notFound("workload policy", req.Msg.Policy.PolicyId)
+	}
+
+	policy := clone(req.Msg.Policy)
+	policy.TeamId = req.Msg.TeamId
+	s.workloadPolicies[policy.PolicyId] = policy
+
+	return connect.NewResponse(&apiv1.UpdateWorkloadRecommendationPolicyResponse{Policy: clone(policy)}), nil
+}
+
+func (s *recommendationService) DeleteWorkloadRecommendationPolicy(ctx context.Context, req *connect.Request[apiv1.DeleteWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.DeleteWorkloadRecommendationPolicyResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, ok := s.workloadPolicies[req.Msg.PolicyId]
+	if !ok || policy.TeamId != req.Msg.TeamId {
+		return nil, notFound("workload policy", req.Msg.PolicyId)
+	}
+
+	delete(s.workloadPolicies, req.Msg.PolicyId)
+
+	return connect.NewResponse(&apiv1.DeleteWorkloadRecommendationPolicyResponse{Success: true}), nil
+}
+
+// Workload policy targets
+
+func (s *recommendationService) CreateWorkloadPolicyTarget(ctx context.Context, req *connect.Request[apiv1.CreateWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.CreateWorkloadPolicyTargetResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if policy, ok := s.workloadPolicies[req.Msg.PolicyId]; !ok || policy.TeamId != req.Msg.TeamId {
+		return nil, notFound("workload policy", req.Msg.PolicyId)
+	}
+
+	now := timestamppb.Now()
+	target := &apiv1.WorkloadPolicyTarget{
+		TargetId:           newID(),
+		PolicyId:           req.Msg.PolicyId,
+		TeamId:             req.Msg.TeamId,
+		Name:               req.Msg.Name,
+		Description:        req.Msg.Description,
+		Priority:           req.Msg.Priority,
+		Enabled:            req.Msg.Enabled,
+		NamespaceSelector:  clone(req.Msg.NamespaceSelector),
+		WorkloadSelector:   clone(req.Msg.WorkloadSelector),
+		KindFilter:         req.Msg.KindFilter,
+		NamePattern:        clone(req.Msg.NamePattern),
+		AnnotationSelector: clone(req.Msg.AnnotationSelector),
+		WorkloadNames:      req.Msg.WorkloadNames,
+		NamespacePattern:   clone(req.Msg.NamespacePattern),
+		NodeGroupNames:     req.Msg.NodeGroupNames,
+		ClusterIds:         req.Msg.ClusterIds,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	s.workloadPolicyTargets[target.TargetId] = target
+
+	return connect.NewResponse(&apiv1.CreateWorkloadPolicyTargetResponse{Target: clone(target)}), nil
+}
+
+func (s *recommendationService) GetWorkloadPolicyTarget(ctx context.Context, req *connect.Request[apiv1.GetWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.GetWorkloadPolicyTargetResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target, ok := s.workloadPolicyTargets[req.Msg.TargetId]
+	if !ok || target.TeamId != req.Msg.TeamId {
+		return nil, notFound("workload policy target", req.Msg.TargetId)
+	}
+
+	return connect.NewResponse(&apiv1.GetWorkloadPolicyTargetResponse{Target: clone(target)}), nil
+}
+
+func (s *recommendationService) UpdateWorkloadPolicyTarget(ctx context.Context, req *connect.Request[apiv1.UpdateWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.UpdateWorkloadPolicyTargetResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target, ok := s.workloadPolicyTargets[req.Msg.TargetId]
+	if !ok || target.TeamId != req.Msg.TeamId {
+		return nil, notFound("workload policy target", req.Msg.TargetId)
+	}
+
+	if req.Msg.PolicyId != nil {
+		if policy, ok := s.workloadPolicies[*req.Msg.PolicyId]; !ok || policy.TeamId != req.Msg.TeamId {
+			return nil, notFound("workload policy", *req.Msg.PolicyId)
+		}
+		target.PolicyId = *req.Msg.PolicyId
+	}
+
+	target.Name = req.Msg.Name
+	target.Description = req.Msg.Description
+	target.Priority = req.Msg.Priority
+	target.Enabled = req.Msg.Enabled
+	target.NamespaceSelector = clone(req.Msg.NamespaceSelector)
+	target.WorkloadSelector = clone(req.Msg.WorkloadSelector)
+	target.KindFilter = req.Msg.KindFilter
+	target.NamePattern = clone(req.Msg.NamePattern)
+	target.AnnotationSelector = clone(req.Msg.AnnotationSelector)
+	target.WorkloadNames = req.Msg.WorkloadNames
+	target.NamespacePattern = clone(req.Msg.NamespacePattern)
+	target.NodeGroupNames = req.Msg.NodeGroupNames
+	target.ClusterIds = req.Msg.ClusterIds
+	target.UpdatedAt = timestamppb.Now()
+
+	return connect.NewResponse(&apiv1.UpdateWorkloadPolicyTargetResponse{Target: clone(target)}), nil
+}
+
+func (s *recommendationService) DeleteWorkloadPolicyTarget(ctx context.Context, req *connect.Request[apiv1.DeleteWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.DeleteWorkloadPolicyTargetResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range req.Msg.TargetIds {
+		target, ok := s.workloadPolicyTargets[id]
+		if !ok || target.TeamId != req.Msg.TeamId {
+			return nil, notFound("workload policy target", id)
+		}
+	}
+	for _, id := range req.Msg.TargetIds {
+		delete(s.workloadPolicyTargets, id)
+	}
+
+	return connect.NewResponse(&apiv1.DeleteWorkloadPolicyTargetResponse{Success: true}), nil
+}
+
+// Node policies
+
+func (s *recommendationService) CreateNodePolicies(ctx context.Context, req *connect.Request[apiv1.CreateNodePoliciesRequest]) (*connect.Response[apiv1.CreateNodePoliciesResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policies := make([]*apiv1.NodePolicy, 0, len(req.Msg.Policies))
+	for _, p := range req.Msg.Policies {
+		policy := clone(p)
+		policy.Id = newID()
+		policy.TeamId = req.Msg.TeamId
+		s.nodePolicies[policy.Id] = policy
+		policies = append(policies, clone(policy))
+	}
+
+	return connect.NewResponse(&apiv1.CreateNodePoliciesResponse{Policies: policies}), nil
+}
+
+func (s *recommendationService) ListNodePolicies(ctx context.Context, req *connect.Request[apiv1.ListNodePoliciesRequest]) (*connect.Response[apiv1.ListNodePoliciesResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var policies []*apiv1.NodePolicy
+	for _, policy := range s.nodePolicies {
+		if policy.TeamId == req.Msg.TeamId {
+			policies = append(policies, clone(policy))
+		}
+	}
+
+	return connect.NewResponse(&apiv1.ListNodePoliciesResponse{Policies: policies}), nil
+}
+
+func (s *recommendationService) UpdateNodePolicy(ctx context.Context, req *connect.Request[apiv1.UpdateNodePolicyRequest]) (*connect.Response[apiv1.UpdateNodePolicyResponse], error) {
+	if req.Msg.Policy == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("policy is required"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.nodePolicies[req.Msg.Policy.Id]
+	if !ok || existing.TeamId != req.Msg.TeamId {
+		return nil, notFound("node policy", req.Msg.Policy.Id)
+	}
+
+	policy := clone(req.Msg.Policy)
+	policy.TeamId = req.Msg.TeamId
+	s.nodePolicies[policy.Id] = policy
+
+	return connect.NewResponse(&apiv1.UpdateNodePolicyResponse{Policy: clone(policy)}), nil
+}
+
+func (s *recommendationService) DeleteNodePolicy(ctx context.Context, req *connect.Request[apiv1.DeleteNodePolicyRequest]) (*connect.Response[apiv1.DeleteNodePolicyResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, ok := s.nodePolicies[req.Msg.PolicyId]
+	if !ok || policy.TeamId != req.Msg.TeamId {
+		return nil, notFound("node policy", req.Msg.PolicyId)
+	}
+
+	delete(s.nodePolicies, req.Msg.PolicyId)
+
+	// Targets attached to the policy are cascade-deleted, like in the backend.
+	var deleted int32
+	for id, target := range s.nodePolicyTargets {
+		if target.PolicyId == req.Msg.PolicyId {
+			delete(s.nodePolicyTargets, id)
+			deleted++
+		}
+	}
+
+	return connect.NewResponse(&apiv1.DeleteNodePolicyResponse{Success: true, DeletedTargetCount: deleted}), nil
+}
+
+// Node policy targets
+
+func (s *recommendationService) CreateNodePolicyTargets(ctx context.Context, req *connect.Request[apiv1.CreateNodePolicyTargetsRequest]) (*connect.Response[apiv1.CreateNodePolicyTargetsResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	targets := make([]*apiv1.NodePolicyTarget, 0, len(req.Msg.Targets))
+	for _, t := range req.Msg.Targets {
+		if err := s.validateNodePolicyTarget(t); err != nil {
+			return nil, err
+		}
+
+		target := clone(t)
+		target.TargetId = newID()
+		target.CreatedAt = timestamppb.Now()
+		target.UpdatedAt = target.CreatedAt
+		s.nodePolicyTargets[target.TargetId] = target
+		targets = append(targets, clone(target))
+	}
+
+	return connect.NewResponse(&apiv1.CreateNodePolicyTargetsResponse{Targets: targets}), nil
+}
+
+func (s *recommendationService) ListNodePolicyTargets(ctx context.Context, req *connect.Request[apiv1.ListNodePolicyTargetsRequest]) (*connect.Response[apiv1.ListNodePolicyTargetsResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var targets []*apiv1.NodePolicyTarget
+	for _, target := range s.nodePolicyTargets {
+		if target.TeamId == req.Msg.TeamId {
+			targets = append(targets, clone(target))
+		}
+	}
+
+	return connect.NewResponse(&apiv1.ListNodePolicyTargetsResponse{Targets: targets}), nil
+}
+
+func (s *recommendationService) UpdateNodePolicyTarget(ctx context.Context, req *connect.Request[apiv1.UpdateNodePolicyTargetRequest]) (*connect.Response[apiv1.UpdateNodePolicyTargetResponse], error) {
+	if req.Msg.Target == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("target is required"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.nodePolicyTargets[req.Msg.Target.TargetId]
+	if !ok || existing.TeamId != req.Msg.Target.TeamId {
+		return nil, notFound("node policy target", req.Msg.Target.TargetId)
+	}
+	if err := s.validateNodePolicyTarget(req.Msg.Target); err != nil {
+		return nil, err
+	}
+
+	target := clone(req.Msg.Target)
+	target.CreatedAt = existing.CreatedAt
+	target.UpdatedAt = timestamppb.Now()
+	s.nodePolicyTargets[target.TargetId] = target
+
+	return connect.NewResponse(&apiv1.UpdateNodePolicyTargetResponse{Target: clone(target)}), nil
+}
+
+// validateNodePolicyTarget enforces the backend invariants on node policy targets. Callers must hold s.mu.
+func (s *Server) validateNodePolicyTarget(target *apiv1.NodePolicyTarget) error {
+	if len(target.ClusterIds) > 1 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("a node policy target supports at most one cluster"))
+	}
+	if policy, ok := s.nodePolicies[target.PolicyId]; !ok || policy.TeamId != target.TeamId {
+		return notFound("node policy", target.PolicyId)
+	}
+	return nil
+}
+
+// Cluster optimizations
+
+func (s *recommendationService) RemoveClusterOptimizations(ctx context.Context, req *connect.Request[apiv1.RemoveClusterOptimizationsRequest]) (*connect.Response[apiv1.RemoveClusterOptimizationsResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookupCluster(req.Msg.TeamId, req.Msg.ClusterId); err != nil {
+		return nil, err
+	}
+
+	// The fake server produces no recommendations, so only targets are disabled
+	var disabled int64
+	for _, target := range s.workloadPolicyTargets {
+		if target.TeamId == req.Msg.TeamId && target.Enabled && slices.Contains(target.ClusterIds, req.Msg.ClusterId) {
+			target.Enabled = false
+			disabled++
+		}
+	}
+	for _, target := range s.nodePolicyTargets {
+		if target.TeamId == req.Msg.TeamId && target.Enabled && slices.Contains(target.ClusterIds, req.Msg.ClusterId) {
+			target.Enabled = false
+			disabled++
+		}
+	}
+
+	return connect.NewResponse(&apiv1.RemoveClusterOptimizationsResponse{
+		Success:         true,
+		DisabledTargets: disabled,
+	}), nil
+}
+
+// Workload rules
+
+func (s *recommendationService) UpsertManualWorkloadRule(ctx context.Context, req *connect.Request[apiv1.UpsertManualWorkloadRuleRequest]) (*connect.Response[apiv1.UpsertManualWorkloadRuleResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookupCluster(req.Msg.TeamId, req.Msg.ClusterId); err != nil {
+		return nil, err
+	}
+
+	// A workload has at most one rule, so upserting the same workload replaces it.
+	rule := &apiv1.WorkloadRule{RuleId: newID(), CreatedAt: timestamppb.Now()}
+	for _, existing := range s.workloadRules {
+		if existing.ClusterId == req.Msg.ClusterId && existing.Namespace == req.Msg.Namespace &&
+			existing.Kind == req.Msg.Kind && existing.Name == req.Msg.Name {
+			rule = &apiv1.WorkloadRule{RuleId: existing.RuleId, CreatedAt: existing.CreatedAt}
+			break
+		}
+	}
+
+	rule.ClusterId = req.Msg.ClusterId
+	rule.Namespace = req.Msg.Namespace
+	rule.Kind = req.Msg.Kind
+	rule.Name = req.Msg.Name
+	rule.Status = "active"
+	rule.SyncStatus = "pending"
+	rule.CurrentSource = ruleSource(req.Msg.Source)
+	rule.UpdatedAt = timestamppb.Now()
+
+	if fields := req.Msg.Fields; fields != nil && !req.Msg.AutoGenerate {
+		rule.CpuRule = clone(fields.CpuRule)
+		rule.MemoryRule = clone(fields.MemoryRule)
+		rule.GpuRule = clone(fields.GpuRule)
+		rule.HpaRule = clone(fields.HpaRule)
+		rule.EmergencyResponse = clone(fields.EmergencyResponse)
+		rule.ActionTriggers = fields.ActionTriggers
+		rule.StartupPeriodSeconds = fields.StartupPeriodSeconds
+		rule.CronSchedule = fields.CronSchedule
+		rule.CooldownMinutes = fields.CooldownMinutes
+		rule.DetectionTriggers = fields.DetectionTriggers
+		rule.SchedulerPlugins = fields.SchedulerPlugins
+		rule.DefragmentationSchedule = fields.DefragmentationSchedule
+		rule.LiveMigrationEnabled = fields.LiveMigrationEnabled
+		rule.UseInPlaceVerticalScaling = fields.UseInPlaceVerticalScaling
+		for _, container := range fields.Containers {
+			rule.Containers = append(rule.Containers, clone(container))
+		}
+	}
+
+	s.workloadRules[rule.RuleId] = rule
+
+	return connect.NewResponse(&apiv1.UpsertManualWorkloadRuleResponse{Rule: clone(rule)}), nil
+}
+
+func (s *recommendationService) GetWorkloadRuleByID(ctx context.Context, req *connect.Request[apiv1.GetWorkloadRuleByIDRequest]) (*connect.Response[apiv1.GetWorkloadRuleByIDResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, cluster, err := s.lookupWorkloadRule(req.Msg.TeamId, req.Msg.RuleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&apiv1.GetWorkloadRuleByIDResponse{
+		Rule:        clone(rule),
+		ClusterName: cluster.DisplayName,
+	}), nil
+}
+
+func (s *recommendationService) GetWorkloadRuleByWorkload(ctx context.Context, req *connect.Request[apiv1.GetWorkloadRuleByWorkloadRequest]) (*connect.Response[apiv1.GetWorkloadRuleByWorkloadResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, err := s.lookupCluster(req.Msg.TeamId, req.Msg.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range s.workloadRules {
+		if rule.ClusterId == req.Msg.ClusterId && rule.Namespace == req.Msg.Namespace && rule.Kind == req.Msg.Kind && rule.Name == req.Msg.WorkloadName {
+			return connect.NewResponse(&apiv1.GetWorkloadRuleByWorkloadResponse{
+				Rule:        clone(rule),
+				ClusterName: cluster.DisplayName,
+				Found:       true,
+			}), nil
+		}
+	}
+
+	return connect.NewResponse(&apiv1.GetWorkloadRuleByWorkloadResponse{ClusterName: cluster.DisplayName}), nil
+}
+
+func (s *recommendationService) DeleteWorkloadRule(ctx context.Context, req *connect.Request[apiv1.DeleteWorkloadRuleRequest]) (*connect.Response[apiv1.DeleteWorkloadRuleResponse], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, _, err := s.lookupWorkloadRule(req.Msg.TeamId, req.Msg.RuleId); err != nil {
+		return nil, err
+	}
+
+	delete(s.workloadRules, req.Msg.RuleId)
+
+	return connect.NewResponse(&apiv1.DeleteWorkloadRuleResponse{}), nil
+}
+
+// lookupWorkloadRule returns a rule together with its cluster, which must be
+// owned by the team. Callers must hold s.mu.
+func (s *Server) lookupWorkloadRule(teamID, ruleID string) (*apiv1.WorkloadRule, *apiv1.Cluster, error) {
+	rule, ok := s.workloadRules[ruleID]
+	if !ok {
+		return nil, nil, notFound("workload rule", ruleID)
+	}
+	cluster, ok := s.clusters[rule.ClusterId]
+	if !ok || cluster.TeamId != teamID {
+		return nil, nil, notFound("workload rule", ruleID)
+	}
+	return rule, cluster, nil
+}
+
+// ruleSource returns the current_source reported for rules upserted from the given source.
+func ruleSource(source apiv1.WorkloadRuleSource) string {
+	if source == apiv1.WorkloadRuleSource_WORKLOAD_RULE_SOURCE_UNSPECIFIED {
+		source = apiv1.WorkloadRuleSource_WORKLOAD_RULE_SOURCE_MANUAL
+	}
+	return strings.ToLower(strings.TrimPrefix(source.String(), "WORKLOAD_RULE_SOURCE_"))
+}
+
+func notFound(kind, id string) error {
+	return connect.NewError(connect.CodeNotFound, fmt.Errorf("%s %q not found", kind, id))
+}
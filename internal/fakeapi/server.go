@@ -0,0 +1,167 @@
+// Package fakeapi implements an in-memory Devzero API for offline acceptance tests.
+//
+// The server speaks every protocol supported by connect-go and keeps all
+// objects in memory, so tests can run the provider end to end without a
+// Devzero account or network access.
+package fakeapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"google.golang.org/protobuf/proto"
+
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
+)
+
+// Server is an in-memory implementation of the Devzero services used by the provider.
+type Server struct {
+	// URL is the base URL of the server, suitable for the provider url argument.
+	URL string
+
+	httpServer *httptest.Server
+
+	mu                    sync.Mutex
+	clusters              map[string]*apiv1.Cluster
+	clusterTokens         map[string]string
+	clusterK8SProviders   map[string]string
+	workloadPolicies      map[string]*apiv1.WorkloadRecommendationPolicy
+	workloadPolicyTargets map[string]*apiv1.WorkloadPolicyTarget
+	nodePolicies          map[string]*apiv1.NodePolicy
+	nodePolicyTargets     map[string]*apiv1.NodePolicyTarget
+	workloadRules         map[string]*apiv1.WorkloadRule
+
+	// tokenTeam is the team owning the API token, used by RPCs that are
+	// scoped by the token rather than by a team_id.
+	tokenTeam string
+
+	// connectAfter is the number of GetCluster calls after which a cluster
+	// reports its operator as connected, or zero to keep clusters disconnected.
+	connectAfter int
+	clusterPolls map[string]int
+}
+
+// NewServer starts a server that is shut down when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		clusters:              make(map[string]*apiv1.Cluster),
+		clusterTokens:         make(map[string]string),
+		clusterK8SProviders:   make(map[string]string),
+		workloadPolicies:      make(map[string]*apiv1.WorkloadRecommendationPolicy),
+		workloadPolicyTargets: make(map[string]*apiv1.WorkloadPolicyTarget),
+		nodePolicies:          make(map[string]*apiv1.NodePolicy),
+		nodePolicyTargets:     make(map[string]*apiv1.NodePolicyTarget),
+		workloadRules:         make(map[string]*apiv1.WorkloadRule),
+		clusterPolls:          make(map[string]int),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewClusterMutationServiceHandler(&clusterMutationService{Server: s}))
+	mux.Handle(apiv1connect.NewK8SServiceHandler(&k8sService{Server: s}))
+	mux.Handle(apiv1connect.NewClusterServiceHandler(&clusterService{Server: s}))
+	mux.Handle(apiv1connect.NewK8SRecommendationServiceHandler(&recommendationService{Server: s}))
+
+	s.httpServer = httptest.NewServer(mux)
+	s.URL = s.httpServer.URL
+	t.Cleanup(s.httpServer.Close)
+
+	return s
+}
+
+// ConnectClustersAfter makes every cluster report its operator as connected
+// once it has been read polls times through GetCluster, simulating an
+// operator that takes a while to install.
+func (s *Server) ConnectClustersAfter(polls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connectAfter = polls
+}
+
+// SetTokenTeam sets the team owning the API token. RPCs without a team_id,
+// such as CreateClusterToken, fail until it is set.
+func (s *Server) SetTokenTeam(teamID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenTeam = teamID
+}
+
+// Cluster returns a copy of the cluster with the given ID, or nil if it does not exist.
+func (s *Server) Cluster(id string) *apiv1.Cluster {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.clusters[id])
+}
+
+// ClusterToken returns the current token of the cluster with the given ID.
+func (s *Server) ClusterToken(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clusterTokens[id]
+}
+
+// ClusterK8SProvider returns the Kubernetes provider the cluster with the
+// given ID was created with through CreateClusterToken.
+func (s *Server) ClusterK8SProvider(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clusterK8SProviders[id]
+}
+
+// WorkloadPolicy returns a copy of the workload policy with the given ID, or nil if it does not exist.
+func (s *Server) WorkloadPolicy(id string) *apiv1.WorkloadRecommendationPolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.workloadPolicies[id])
+}
+
+// WorkloadPolicyTarget returns a copy of the workload policy target with the given ID, or nil if it does not exist.
+func (s *Server) WorkloadPolicyTarget(id string) *apiv1.WorkloadPolicyTarget {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.workloadPolicyTargets[id])
+}
+
+// NodePolicy returns a copy of the node policy with the given ID, or nil if it does not exist.
+func (s *Server) NodePolicy(id string) *apiv1.NodePolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.nodePolicies[id])
+}
+
+// NodePolicyTarget returns a copy of the node policy target with the given ID, or nil if it does not exist.
+func (s *Server) NodePolicyTarget(id string) *apiv1.NodePolicyTarget {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.nodePolicyTargets[id])
+}
+
+// WorkloadRule returns a copy of the workload rule with the given ID, or nil if it does not exist.
+func (s *Server) WorkloadRule(id string) *apiv1.WorkloadRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clone(s.workloadRules[id])
+}
+
+// clone deep-copies a message so that callers never share memory with the server state.
+func clone[M proto.Message](m M) M {
+	var zero M
+	if any(m) == any(zero) {
+		return zero
+	}
+	return proto.Clone(m).(M)
+}
+
+// newID returns a random UUID, like the IDs issued by the Devzero backend.
+func newID() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ClusterOptimizationsDisabledResource{}
+var _ resource.ResourceWithConfigure = &ClusterOptimizationsDisabledResource{}
+
+func NewClusterOptimizationsDisabledResource() resource.Resource {
+	return &ClusterOptimizationsDisabledResource{}
+}
+
+// ClusterOptimizationsDisabledResource defines the resource implementation.
+type ClusterOptimizationsDisabledResource struct {
+	client *ClientSet
+}
+
+// ClusterOptimizationsDisabledResourceModel describes the resource data model.
+type ClusterOptimizationsDisabledResourceModel struct {
+	Id                            types.String `tfsdk:"id"`
+	TeamId                        types.String `tfsdk:"team_id"`
+	ClusterId                     types.String `tfsdk:"cluster_id"`
+	Triggers                      types.Map    `tfsdk:"triggers"`
+	DisabledTargets               types.Int64  `tfsdk:"disabled_targets"`
+	DeletedPendingRecommendations types.Int64  `tfsdk:"deleted_pending_recommendations"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ClusterOptimizationsDisabledResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_optimizations_disabled"
+}
+
+func (r *ClusterOptimizationsDisabledResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Removes the Devzero optimizations of a cluster while keeping it registered: every policy target applying to the cluster is disabled and its pending recommendations are deleted. " +
+			"Use it as a kill switch during incidents, or to decommission a cluster.\n\n" +
+			"~> **Note** Destroying this resource only removes it from the Terraform state. The disabled targets are not re-enabled, and targets managed by Terraform plan to re-enable them through their `enabled` attribute on the next apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the cluster whose optimizations are disabled",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"team_id": teamIDAttribute(),
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the cluster to disable the optimizations of. Changing it forces a new resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, removes the optimizations again, e.g. to disable targets created since.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"disabled_targets": schema.Int64Attribute{
+				MarkdownDescription: "Number of policy targets disabled when the optimizations were removed",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"deleted_pending_recommendations": schema.Int64Attribute{
+				MarkdownDescription: "Number of pending recommendations deleted when the optimizations were removed",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ClusterOptimizationsDisabledResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ClientSet)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterOptimizationsDisabledResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ClusterOptimizationsDisabledResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "create", createTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
+	removeResp, err := removeClusterOptimizations(ctx, r.client, teamId, data.ClusterId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove cluster optimizations, got error: %s", err))
+		return
+	}
+
+	data.Id = data.ClusterId
+	data.DisabledTargets = types.Int64Value(removeResp.DisabledTargets)
+	data.DeletedPendingRecommendations = types.Int64Value(removeResp.DeletedPendingRecommendations)
+
+	tflog.Trace(ctx, "removed cluster optimizations", map[string]any{
+		"cluster_id":                      data.ClusterId.ValueString(),
+		"disabled_targets":                removeResp.DisabledTargets,
+		"deleted_pending_recommendations": removeResp.DeletedPendingRecommendations,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterOptimizationsDisabledResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ClusterOptimizationsDisabledResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "read", readTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
+	// The API does not report whether optimizations are disabled, so only
+	// check that the cluster still exists
+	getClusterReq := &apiv1.GetClusterRequest{
+		TeamId:    teamId,
+		ClusterId: data.Id.ValueString(),
+	}
+
+	getClusterResp, err := r.client.K8SServiceClient.GetCluster(ctx, connect.NewRequest(getClusterReq))
+	if err != nil {
+		if isNotFound(err) {
+			removeMissingResource(ctx, &resp.State, "Cluster", data.Id.ValueString())
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster, got error: %s", err))
+		return
+	}
+
+	if getClusterResp.Msg.Cluster == nil {
+		removeMissingResource(ctx, &resp.State, "Cluster", data.Id.ValueString())
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterOptimizationsDisabledResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ClusterOptimizationsDisabledResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other attribute forces a new resource, so only timeouts can change
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterOptimizationsDisabledResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ClusterOptimizationsDisabledResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// No-op delete: there is no API to restore the optimizations of a cluster
+	tflog.Warn(ctx, "Cluster optimizations disabled delete is a no-op operation. The disabled targets stay disabled.", map[string]any{
+		"cluster_id": data.ClusterId.ValueString(),
+	})
+}
+
+// removeClusterOptimizations disables every policy target of the cluster and
+// deletes its pending recommendations.
+func removeClusterOptimizations(ctx context.Context, client *ClientSet, teamId, clusterId string) (*apiv1.RemoveClusterOptimizationsResponse, error) {
+	removeReq := &apiv1.RemoveClusterOptimizationsRequest{
+		TeamId:    teamId,
+		ClusterId: clusterId,
+	}
+
+	removeResp, err := client.RecommendationClient.RemoveClusterOptimizations(ctx, connect.NewRequest(removeReq))
+	if err != nil {
+		return nil, err
+	}
+	if !removeResp.Msg.Success {
+		return nil, fmt.Errorf("the API did not report success")
+	}
+	return removeResp.Msg, nil
+}
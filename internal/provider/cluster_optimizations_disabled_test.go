@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+)
+
+func TestClusterOptimizationsDisabledResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	resp := &resource.SchemaResponse{}
+	NewClusterOptimizationsDisabledResource().Schema(ctx, resource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema had errors: %v", resp.Diagnostics)
+	}
+
+	if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diags)
+	}
+
+	for _, attr := range []string{"id", "team_id", "disabled_targets", "deleted_pending_recommendations"} {
+		if !resp.Schema.Attributes[attr].IsComputed() {
+			t.Errorf("Attribute %s should be computed", attr)
+		}
+	}
+	if !resp.Schema.Attributes["cluster_id"].IsRequired() {
+		t.Error("Attribute cluster_id should be required")
+	}
+}
+
+func TestClusterOptimizationsDisabledResourceRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err     error
+		removed bool
+	}{
+		"cluster deleted": {err: connect.NewError(connect.CodeNotFound, errors.New("cluster not found")), removed: true},
+		"nil cluster":     {removed: true},
+		"unavailable":     {err: connect.NewError(connect.CodeUnavailable, errors.New("service unavailable")), removed: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &ClusterOptimizationsDisabledResource{client: &ClientSet{
+				TeamId: "team-1",
+				K8SServiceClient: &stubK8SServiceClient{
+					getCluster: func(context.Context, *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+						if tt.err != nil {
+							return nil, tt.err
+						}
+						return connect.NewResponse(&apiv1.GetClusterResponse{}), nil
+					},
+				},
+			}}
+			req, resp := newTestReadRequest(t, r, "cluster-1")
+			r.Read(context.Background(), req, resp)
+			if tt.removed {
+				assertRemovedFromState(t, resp)
+			} else {
+				assertKeptInState(t, resp)
+			}
+		})
+	}
+}
+
+func TestAccClusterOptimizationsDisabledResource(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccClusterOptimizationsDisabledResourceConfig(server),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrPair("devzero_cluster_optimizations_disabled.test", "id", "devzero_cluster.test", "id"),
+					tfresource.TestCheckResourceAttr("devzero_cluster_optimizations_disabled.test", "disabled_targets", "1"),
+					tfresource.TestCheckResourceAttr("devzero_cluster_optimizations_disabled.test", "deleted_pending_recommendations", "0"),
+					testAccCheckNodePolicyTargetEnabled(server, "devzero_node_policy_target.test", false),
+				),
+				// The node policy target drifts from its configured enabled = true
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccClusterOptimizationsDisabledResourceConfig(server *fakeapi.Server) string {
+	return testAccProviderConfig(server) + `
+resource "devzero_cluster" "test" {
+  name = "acc-cluster"
+}
+
+resource "devzero_node_policy" "test" {
+  name = "acc-node-policy"
+}
+
+resource "devzero_node_policy_target" "test" {
+  name        = "acc-node-target"
+  policy_id   = devzero_node_policy.test.id
+  cluster_ids = [devzero_cluster.test.id]
+}
+
+resource "devzero_cluster_optimizations_disabled" "test" {
+  cluster_id = devzero_cluster.test.id
+
+  depends_on = [devzero_node_policy_target.test]
+}
+`
+}
+
+// testAccCheckNodePolicyTargetEnabled verifies the enabled flag of a node policy target in the fake API server.
+func testAccCheckNodePolicyTargetEnabled(server *fakeapi.Server, resourceName string, enabled bool) tfresource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		target := server.NodePolicyTarget(rs.Primary.ID)
+		if target == nil {
+			return fmt.Errorf("node policy target %s not found in the API", rs.Primary.ID)
+		}
+		if target.Enabled != enabled {
+			return fmt.Errorf("expected node policy target enabled %t in the API, got %t", enabled, target.Enabled)
+		}
+		return nil
+	}
+}
@@ -3,13 +3,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -33,11 +41,56 @@ type ClusterResource struct {
 
 // ExampleResourceModel describes the resource data model.
 type ClusterResourceModel struct {
-	Id    types.String `tfsdk:"id"`
-	Name  types.String `tfsdk:"name"`
-	Token types.String `tfsdk:"token"`
+	Id          types.String `tfsdk:"id"`
+	TeamId      types.String `tfsdk:"team_id"`
+	Name        types.String `tfsdk:"name"`
+	K8sProvider types.String `tfsdk:"k8s_provider"`
+	Token       types.String `tfsdk:"token"`
+	Tags        types.Set    `tfsdk:"tags"`
+	TagsAll     types.Set    `tfsdk:"tags_all"`
+
+	StoreToken       types.Bool   `tfsdk:"store_token"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+	RotateTokenAfter types.String `tfsdk:"rotate_token_after"`
+	TokenRotatedAt   types.String `tfsdk:"token_rotated_at"`
+
+	DisplayName         types.String `tfsdk:"display_name"`
+	CloudProvider       types.String `tfsdk:"cloud_provider"`
+	Region              types.String `tfsdk:"region"`
+	KubernetesVersion   types.String `tfsdk:"kubernetes_version"`
+	IsDisconnected      types.Bool   `tfsdk:"is_disconnected"`
+	HasArgoWorkloads    types.Bool   `tfsdk:"has_argo_workloads"`
+	CreatedAt           types.String `tfsdk:"created_at"`
+	UpdatedAt           types.String `tfsdk:"updated_at"`
+	DakrOperatorVersion types.String `tfsdk:"dakr_operator_version"`
+	NodeOperatorVersion types.String `tfsdk:"node_operator_version"`
+	ZxpVersion          types.String `tfsdk:"zxp_version"`
+
+	RemoveOptimizationsOnDestroy types.Bool              `tfsdk:"remove_optimizations_on_destroy"`
+	DeletionProtection           types.Bool              `tfsdk:"deletion_protection"`
+	WaitForConnection            *WaitForConnectionModel `tfsdk:"wait_for_connection"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
+type WaitForConnectionModel struct {
+	Timeout      types.String `tfsdk:"timeout"`
+	PollInterval types.String `tfsdk:"poll_interval"`
+}
+
+// k8sProviders are the Kubernetes providers accepted by CreateClusterToken.
+var k8sProviders = []string{"aws", "azure", "gcp", "oci", "other"}
+
+// Defaults of the wait_for_connection attribute.
+const (
+	defaultWaitForConnectionTimeout      = 10 * time.Minute
+	defaultWaitForConnectionPollInterval = 10 * time.Second
+)
+
+// waitForConnectionMargin is the time left to the create or update operation
+// after waiting for the operator, so that its own deadline is not hit.
+const waitForConnectionMargin = 10 * time.Second
+
 func (r *ClusterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_cluster"
 }
@@ -55,18 +108,156 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"team_id": teamIDAttribute(),
 			"name": schema.StringAttribute{
 				Description: "Name of the cluster",
 				Required:    true,
 			},
+			"k8s_provider": schema.StringAttribute{
+				MarkdownDescription: "Kubernetes provider of the cluster, one of `aws`, `azure`, `gcp`, `oci` or `other`, so that Devzero applies the matching defaults. " +
+					"When set, the cluster is created in the team of the provider API token, so `team_id` must be left unset or match the provider `team_id`. " +
+					"It is only sent to the API when creating the cluster: changing it from one provider to another forces a new resource, " +
+					"while setting it on an existing cluster, e.g. after import, is only recorded in the state and reported as a warning during plan.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(k8sProviders...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsNull()
+					}, "Changing the Kubernetes provider of a cluster forces a new resource.", "Changing the Kubernetes provider of a cluster forces a new resource."),
+				},
+			},
 			"token": schema.StringAttribute{
-				Description: "Token of the cluster",
+				Description: "Token of the cluster. Null when store_token is false.",
 				Computed:    true,
 				Sensitive:   true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"store_token": schema.BoolAttribute{
+				MarkdownDescription: "Whether to store the cluster `token` in the Terraform state. Set to `false` to keep the token out of the state, and issue the operator token with the `devzero_cluster_token` ephemeral resource instead. " +
+					"As the token issued on creation is then discarded, plans that would rotate the token through `rotation_triggers` or `rotate_token_after` fail while this is `false`. " +
+					"Setting it back to `true` issues a new token on the next apply. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"rotation_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, rotates the cluster `token` through a token reset. Setting the map for the first time, or removing it, does not rotate the token. The Devzero operator must be reinstalled with the new token.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"rotate_token_after": schema.StringAttribute{
+				MarkdownDescription: "Rotate the cluster `token` on the first apply after it gets older than this duration (e.g. `720h`), based on `token_rotated_at`. Tokens without a recorded rotation time, e.g. of imported clusters, are rotated on the next apply.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"token_rotated_at": schema.StringAttribute{
+				MarkdownDescription: "Time the cluster `token` was issued by Terraform, in RFC 3339 format",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags of the cluster. Merged with the provider `default_tags` into `tags_all`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tags_all": schema.SetAttribute{
+				MarkdownDescription: "All tags of the cluster, including those inherited from the provider `default_tags`",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"display_name": schema.StringAttribute{
+				Description: "Name of the cluster as shown in the Devzero console",
+				Computed:    true,
+			},
+			"cloud_provider": schema.StringAttribute{
+				Description: "Cloud provider the cluster runs on, as reported by the Devzero operator",
+				Computed:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "Cloud region the cluster runs in, as reported by the Devzero operator",
+				Computed:    true,
+			},
+			"kubernetes_version": schema.StringAttribute{
+				Description: "Kubernetes version of the cluster (e.g. v1.28.3)",
+				Computed:    true,
+			},
+			"is_disconnected": schema.BoolAttribute{
+				Description: "Whether the Devzero operator has stopped reporting from the cluster. Null until the operator first connects.",
+				Computed:    true,
+			},
+			"has_argo_workloads": schema.BoolAttribute{
+				Description: "Whether any workload on the cluster is managed by Argo CD",
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Creation time of the cluster, in RFC 3339 format",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "Last update time of the cluster, in RFC 3339 format",
+				Computed:    true,
+			},
+			"dakr_operator_version": schema.StringAttribute{
+				Description: "Version of the Devzero operator installed in the cluster. Null until the operator is installed.",
+				Computed:    true,
+			},
+			"node_operator_version": schema.StringAttribute{
+				Description: "Version of the Devzero node operator installed in the cluster. Null until the node operator is installed.",
+				Computed:    true,
+			},
+			"zxp_version": schema.StringAttribute{
+				Description: "Version of the zxp agent installed in the cluster. Null until the agent is installed.",
+				Computed:    true,
+			},
+			"deletion_protection": deletionProtectionAttribute("cluster"),
+			"remove_optimizations_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to remove the Devzero optimizations of the cluster before deleting it, disabling its policy targets and deleting its pending recommendations. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_connection": schema.SingleNestedAttribute{
+				MarkdownDescription: "Wait after creating the cluster, or rotating its token, until the Devzero operator has connected, i.e. `is_disconnected` is false and `dakr_operator_version` is set. " +
+					"The operator must be installed outside of this resource's dependency chain, as it needs the cluster `token`: waiting for an operator installed by a resource that depends on this cluster never succeeds. " +
+					"If the operator does not connect in time, the apply fails with the last observed state. A cluster created by that apply is saved in the state, " +
+					"but marked as tainted by Terraform, so that the next apply replaces it unless it is untainted with `terraform untaint`. " +
+					"The wait ends early enough for the create or update operation to complete within `timeouts`.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("How long to wait for the operator to connect, as a duration string (e.g. `5m`). Defaults to `%s`.", formatDuration(defaultWaitForConnectionTimeout)),
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+					"poll_interval": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("How often to check whether the operator has connected, as a duration string (e.g. `30s`). Defaults to `%s`.", formatDuration(defaultWaitForConnectionPollInterval)),
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
@@ -92,8 +283,37 @@ func (r *ClusterResource) Configure(ctx context.Context, req resource.ConfigureR
 }
 
 func (r *ClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkDeletionProtection(ctx, req, resp, "cluster")
+
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ClusterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// tags_all can only be computed once the tags and the provider default tags are known
+	if r.client != nil && !plan.Tags.IsUnknown() {
+		if tags, err := getStringList(ctx, plan.Tags.Elements()); err == nil {
+			tagsAll := types.SetValueMust(types.StringType, fromStringList(mergeTags(r.client.DefaultTags, tags)))
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+		}
+	}
+
 	// If the resource is being created, skip forcing a rotation during plan
 	if req.State.Raw.IsNull() {
+		// CreateClusterToken has no team_id and always creates the cluster in the team of the API token
+		if r.client != nil && !plan.K8sProvider.IsNull() && !plan.TeamId.IsUnknown() && !plan.TeamId.IsNull() && plan.TeamId.ValueString() != r.client.TeamId {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("team_id"),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("Clusters created with k8s_provider belong to the team of the provider API token, %q, so team_id must be left unset or match it.", r.client.TeamId),
+			)
+		}
 		return
 	}
 
@@ -103,19 +323,86 @@ func (r *ClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 		return
 	}
 
-	// If the prior token is empty, mark the planned token as unknown so that
-	// Terraform plans an apply which will rotate the token during Update.
-	if data.Token.IsNull() || data.Token.ValueString() == "" {
-		// Only attempt to set if plan is available
-		if !req.Plan.Raw.IsNull() {
-			// Set token to unknown in plan
-			err := resp.Plan.SetAttribute(ctx, path.Root("token"), types.StringUnknown())
-			if err != nil {
-				resp.Diagnostics.AddError("Plan Error", fmt.Sprintf("Unable to mark token unknown in plan: %s", err))
-				return
-			}
-		}
+	// Only changes between providers force a new resource, see the k8s_provider plan modifier
+	if data.K8sProvider.IsNull() && !plan.K8sProvider.IsNull() && !plan.K8sProvider.IsUnknown() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("k8s_provider"),
+			"Kubernetes Provider Not Applied",
+			fmt.Sprintf("k8s_provider is only used when creating a cluster. Setting it on the existing cluster %s records it in the state, "+
+				"but Devzero keeps the defaults the cluster was created with.", data.Id.ValueString()),
+		)
+	}
+
+	// Rotate the token if the prior token is empty, a rotation trigger changed
+	// or the token is older than rotate_token_after. Marking the planned
+	// token_rotated_at as unknown makes Terraform plan an apply which rotates
+	// the token during Update.
+	storeToken := plan.StoreToken.ValueBool()
+
+	var reason string
+	var trigger path.Path
+	switch {
+	case storeToken && (data.Token.IsNull() || data.Token.ValueString() == ""):
+		reason = "the token is empty"
+	case !data.RotationTriggers.IsNull() && !plan.RotationTriggers.IsNull() && !plan.RotationTriggers.Equal(data.RotationTriggers):
+		reason = "rotation_triggers changed"
+		trigger = path.Root("rotation_triggers")
+	case isTokenRotationDue(data.TokenRotatedAt, plan.RotateTokenAfter, time.Now()):
+		reason = "the token is older than rotate_token_after"
+		trigger = path.Root("rotate_token_after")
+	}
+
+	// A rotation without storing the new token would revoke the token of the
+	// running operator and leave nothing to replace it with
+	if reason != "" && !storeToken {
+		resp.Diagnostics.AddAttributeError(
+			trigger,
+			"Token Rotation Requires store_token",
+			fmt.Sprintf("The token of cluster %s would be rotated because %s, but store_token is false, so the new token would be discarded "+
+				"and the Devzero operator left without a valid token. Rotate the token with the devzero_cluster_token ephemeral resource instead, "+
+				"or set store_token to true.", data.Id.ValueString(), reason),
+		)
+		return
+	}
+
+	token := types.StringNull()
+	if storeToken {
+		token = data.Token
 	}
+
+	if reason == "" {
+		// UseStateForUnknown leaves token_rotated_at unknown when it was never recorded
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token"), token)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token_rotated_at"), data.TokenRotatedAt)...)
+		return
+	}
+
+	tflog.Debug(ctx, "Planning cluster token rotation", map[string]any{
+		"cluster_id": data.Id.ValueString(),
+		"reason":     reason,
+	})
+	if storeToken {
+		token = types.StringUnknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token"), token)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token_rotated_at"), types.StringUnknown())...)
+}
+
+// isTokenRotationDue reports whether a token rotated at rotatedAt is older
+// than rotateAfter. A token without a known rotation time is always due.
+func isTokenRotationDue(rotatedAt types.String, rotateAfter types.String, now time.Time) bool {
+	if rotateAfter.IsNull() || rotateAfter.IsUnknown() {
+		return false
+	}
+	if rotatedAt.IsNull() || rotatedAt.IsUnknown() {
+		return true
+	}
+
+	rotated, err := time.Parse(time.RFC3339, rotatedAt.ValueString())
+	if err != nil {
+		return true
+	}
+	return !now.Before(rotated.Add(durationValue(rotateAfter, 0)))
 }
 
 func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -127,24 +414,64 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	createClusterReq := &apiv1.CreateClusterRequest{
-		TeamId:      r.client.TeamId,
-		ClusterName: data.Name.ValueString(),
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	createClusterResp, err := r.client.ClusterMutationClient.CreateCluster(ctx, connect.NewRequest(createClusterReq))
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create cluster, got error: %s", err))
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "create", createTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
+	cluster, token, err := r.createCluster(ctx, teamId, &data)
+	if cluster.GetId() == "" {
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create cluster, got error: %s", err))
+		} else {
+			resp.Diagnostics.AddError("Client Error", "Cluster not created")
+		}
 		return
 	}
-	if createClusterResp.Msg.Cluster == nil || createClusterResp.Msg.Token == "" {
-		resp.Diagnostics.AddError("Client Error", "Cluster not created")
-		return
+
+	// From here on the cluster exists: an error would taint it and replace it
+	// on the next apply, so failures are reported as warnings and the cluster
+	// is always saved in state. Only wait_for_connection fails the apply, as
+	// the operator not connecting is what it is meant to catch.
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Cluster Not Read",
+			fmt.Sprintf("Cluster %s was created, but could not be read back, got error: %s. Its attributes are read on the next refresh.", cluster.Id, err),
+		)
 	}
 
 	// Set the state
-	data.Id = types.StringValue(createClusterResp.Msg.Cluster.Id)
-	data.Token = types.StringValue(createClusterResp.Msg.Token)
+	data.Id = types.StringValue(cluster.Id)
+	data.Token = types.StringValue(token)
+	if !data.StoreToken.ValueBool() {
+		data.Token = types.StringNull()
+	} else if token == "" {
+		// ModifyPlan rotates empty tokens
+		resp.Diagnostics.AddWarning("Cluster Token Missing", fmt.Sprintf("Cluster %s was created without a token. The next apply issues a new one.", cluster.Id))
+	}
+	data.TokenRotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	data.fromProto(cluster)
+
+	// An error would taint the cluster, which exists by now, and replace it on
+	// the next apply. Keep the planned tags instead: the next refresh reads the
+	// actual ones, so the next plan shows the missing tags.
+	for _, d := range r.applyTags(ctx, &data, cluster.Tags).Errors() {
+		resp.Diagnostics.AddWarning(
+			"Cluster Tags Not Applied",
+			fmt.Sprintf("Cluster %s was created, but its tags could not be applied: %s. The next plan shows the missing tags.", data.Id.ValueString(), d.Detail()),
+		)
+	}
+
+	if data.WaitForConnection != nil {
+		resp.Diagnostics.Append(r.waitForConnection(ctx, &data, "create")...)
+	}
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a resource")
@@ -153,6 +480,54 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// createCluster creates the cluster and returns it with its token. Clusters
+// with a k8s_provider are created through CreateClusterToken, which only
+// returns the cluster ID, so the cluster is read back afterwards. If that read
+// fails, the created cluster is returned with only its ID set, along with the
+// error, so that callers can tell a created cluster by its ID.
+func (r *ClusterResource) createCluster(ctx context.Context, teamId string, data *ClusterResourceModel) (*apiv1.Cluster, string, error) {
+	if data.K8sProvider.IsNull() {
+		createClusterReq := &apiv1.CreateClusterRequest{
+			TeamId:      teamId,
+			ClusterName: data.Name.ValueString(),
+		}
+
+		createClusterResp, err := r.client.ClusterMutationClient.CreateCluster(ctx, connect.NewRequest(createClusterReq))
+		if err != nil {
+			return nil, "", err
+		}
+		return createClusterResp.Msg.Cluster, createClusterResp.Msg.Token, nil
+	}
+
+	createTokenReq := &apiv1.CreateClusterTokenRequest{
+		ClusterName: data.Name.ValueString(),
+		K8SProvider: data.K8sProvider.ValueString(),
+	}
+
+	createTokenResp, err := r.client.ClusterServiceClient.CreateClusterToken(ctx, connect.NewRequest(createTokenReq))
+	if err != nil {
+		return nil, "", err
+	}
+	if createTokenResp.Msg.ClusterId == "" {
+		return nil, "", nil
+	}
+
+	getClusterReq := &apiv1.GetClusterRequest{
+		TeamId:    teamId,
+		ClusterId: createTokenResp.Msg.ClusterId,
+	}
+
+	created := &apiv1.Cluster{Id: createTokenResp.Msg.ClusterId}
+	getClusterResp, err := r.client.K8SServiceClient.GetCluster(ctx, connect.NewRequest(getClusterReq))
+	if err != nil {
+		return created, createTokenResp.Msg.Token, err
+	}
+	if getClusterResp.Msg.Cluster == nil {
+		return created, createTokenResp.Msg.Token, fmt.Errorf("cluster %s not found", created.Id)
+	}
+	return getClusterResp.Msg.Cluster, createTokenResp.Msg.Token, nil
+}
+
 func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data ClusterResourceModel
 
@@ -163,19 +538,46 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "read", readTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
+	// Imported and upgraded states have no store_token, remove_optimizations_on_destroy or deletion_protection yet
+	if data.StoreToken.IsNull() {
+		data.StoreToken = types.BoolValue(true)
+	}
+	if data.RemoveOptimizationsOnDestroy.IsNull() {
+		data.RemoveOptimizationsOnDestroy = types.BoolValue(false)
+	}
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
 	getClusterReq := &apiv1.GetClusterRequest{
-		TeamId:    r.client.TeamId,
+		TeamId:    teamId,
 		ClusterId: data.Id.ValueString(),
 	}
 
 	getClusterResp, err := r.client.K8SServiceClient.GetCluster(ctx, connect.NewRequest(getClusterReq))
 	if err != nil {
+		if isNotFound(err) {
+			removeMissingResource(ctx, &resp.State, "Cluster", data.Id.ValueString())
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get cluster, got error: %s", err))
 		return
 	}
 
 	if getClusterResp.Msg.Cluster == nil {
-		resp.Diagnostics.AddError("Client Error", "Cluster not found")
+		removeMissingResource(ctx, &resp.State, "Cluster", data.Id.ValueString())
 		return
 	}
 
@@ -185,6 +587,15 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	data.Name = types.StringValue(name)
+	data.fromProto(getClusterResp.Msg.Cluster)
+
+	tags, err := r.resourceTags(ctx, data.Tags, getClusterResp.Msg.Cluster.Tags)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster tags, got error: %s", err))
+		return
+	}
+	data.Tags = tags
+	data.TagsAll = types.SetValueMust(types.StringType, fromStringList(getClusterResp.Msg.Cluster.Tags))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -200,8 +611,20 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "update", updateTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	updateClusterReq := &apiv1.UpdateClusterRequest{
-		TeamId:      r.client.TeamId,
+		TeamId:      teamId,
 		ClusterId:   data.Id.ValueString(),
 		ClusterName: data.Name.ValueString(),
 	}
@@ -218,11 +641,29 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	data.Name = types.StringValue(updateClusterResp.Msg.Cluster.CustomName)
+	data.fromProto(updateClusterResp.Msg.Cluster)
+
+	var state ClusterResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	currentTags, err := getStringList(ctx, state.TagsAll.Elements())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster tags from state, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.applyTags(ctx, &data, currentTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// If prior token was empty, rotate it now and persist the new token in state
-	if data.Token.IsNull() || data.Token.IsUnknown() || data.Token.ValueString() == "" {
+	// If the plan rotates the token, rotate it now and persist the new token in state
+	if data.TokenRotatedAt.IsUnknown() {
 		resetReq := &apiv1.ResetClusterTokenRequest{
-			TeamId:    r.client.TeamId,
+			TeamId:    teamId,
 			ClusterId: data.Id.ValueString(),
 		}
 		resetResp, err := r.client.ClusterMutationClient.ResetClusterToken(ctx, connect.NewRequest(resetReq))
@@ -235,6 +676,12 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 			return
 		}
 		data.Token = types.StringValue(resetResp.Msg.Token)
+		data.TokenRotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+		// Only a new token can change whether the operator is connected
+		if data.WaitForConnection != nil {
+			resp.Diagnostics.Append(r.waitForConnection(ctx, &data, "update")...)
+		}
 	}
 
 	// Save updated data into Terraform state
@@ -251,8 +698,39 @@ func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if data.DeletionProtection.ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, "cluster", data.Id.ValueString())
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "delete", deleteTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+
+	if data.RemoveOptimizationsOnDestroy.ValueBool() {
+		removeResp, err := removeClusterOptimizations(ctx, r.client, teamId, data.Id.ValueString())
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove cluster optimizations, got error: %s", err))
+			return
+		}
+		if removeResp != nil {
+			tflog.Debug(ctx, "Removed cluster optimizations", map[string]any{
+				"cluster_id":                      data.Id.ValueString(),
+				"disabled_targets":                removeResp.DisabledTargets,
+				"deleted_pending_recommendations": removeResp.DeletedPendingRecommendations,
+			})
+		}
+	}
+
 	deleteClusterReq := &apiv1.DeleteClusterRequest{
-		TeamId:    r.client.TeamId,
+		TeamId:    teamId,
 		ClusterId: data.Id.ValueString(),
 	}
 
@@ -264,5 +742,248 @@ func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithName(ctx, req, resp, "cluster", r.client.teamID, r.lookupIDByName)
+}
+
+// lookupIDByName resolves the name of a cluster to its ID.
+func (r *ClusterResource) lookupIDByName(ctx context.Context, teamId string, name string) (string, error) {
+	// GetClusterIDByName, used by the devzero_cluster_id_by_name data source,
+	// returns a single ID even when several clusters of the team share the
+	// name. Importing the wrong cluster would go unnoticed, so the clusters are
+	// listed instead and matchName rejects ambiguous names.
+	getClustersReq := &apiv1.GetClustersBasicInfoRequest{
+		TeamId: teamId,
+	}
+
+	getClustersResp, err := r.client.ClusterServiceClient.GetClustersBasicInfo(ctx, connect.NewRequest(getClustersReq))
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	for _, cluster := range getClustersResp.Msg.Clusters {
+		if cluster.CustomName == name || cluster.Name == name {
+			ids = append(ids, cluster.Id)
+		}
+	}
+	return matchName("cluster", teamId, name, ids)
+}
+
+// fromProto sets the computed attributes reported by the API. The name and
+// tags are handled by the callers, as they depend on the configuration.
+func (m *ClusterResourceModel) fromProto(cluster *apiv1.Cluster) {
+	m.DisplayName = types.StringValue(cluster.DisplayName)
+	m.CloudProvider = types.StringValue(cluster.CloudProvider)
+	m.Region = types.StringValue(cluster.Region)
+	m.KubernetesVersion = types.StringValue(cluster.KubernetesVersion)
+	m.IsDisconnected = types.BoolPointerValue(cluster.IsDisconnected)
+	m.HasArgoWorkloads = types.BoolValue(cluster.HasArgoWorkloads)
+	m.CreatedAt = unixTimestampValue(cluster.CreatedAt)
+	m.UpdatedAt = unixTimestampValue(cluster.UpdatedAt)
+	m.DakrOperatorVersion = operatorVersionValue(cluster.DakrOpInfo)
+	m.NodeOperatorVersion = operatorVersionValue(cluster.NodeOpInfo)
+	m.ZxpVersion = operatorVersionValue(cluster.ZxpInfo)
+}
+
+// waitForConnection polls the cluster until the Devzero operator has
+// connected, updating the computed attributes with the last observed state.
+// The wait is bounded by the deadline of the operation ("create" or "update")
+// minus waitForConnectionMargin, so that the operation itself does not time
+// out once the wait has failed.
+func (r *ClusterResource) waitForConnection(ctx context.Context, data *ClusterResourceModel, operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	timeout := durationValue(data.WaitForConnection.Timeout, defaultWaitForConnectionTimeout)
+	pollInterval := durationValue(data.WaitForConnection.PollInterval, defaultWaitForConnectionPollInterval)
+
+	advice := "increase wait_for_connection.timeout"
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline) - waitForConnectionMargin; remaining < timeout {
+			timeout = max(remaining, 0).Truncate(time.Second)
+			advice = fmt.Sprintf("increase timeouts.%s, which limits the wait", operation)
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	getClusterReq := &apiv1.GetClusterRequest{
+		TeamId:    data.TeamId.ValueString(),
+		ClusterId: data.Id.ValueString(),
+	}
+
+	var last *apiv1.Cluster
+	for {
+		getClusterResp, err := r.client.K8SServiceClient.GetCluster(waitCtx, connect.NewRequest(getClusterReq))
+		if err != nil && waitCtx.Err() == nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to get cluster while waiting for the operator to connect, got error: %s", err))
+			return diags
+		}
+
+		if err == nil && getClusterResp.Msg.Cluster != nil {
+			last = getClusterResp.Msg.Cluster
+			data.fromProto(last)
+
+			if isClusterConnected(last) {
+				tflog.Info(ctx, "Devzero operator connected", map[string]any{
+					"cluster_id":            last.Id,
+					"dakr_operator_version": last.GetDakrOpInfo().GetVersion(),
+				})
+				return diags
+			}
+
+			tflog.Info(ctx, "Waiting for the Devzero operator to connect", map[string]any{
+				"cluster_id":      last.Id,
+				"is_disconnected": fmt.Sprint(last.IsDisconnected != nil && *last.IsDisconnected),
+				"operator_seen":   last.GetDakrOpInfo().GetVersion() != "",
+			})
+		}
+
+		select {
+		case <-waitCtx.Done():
+			diags.AddError(
+				"Cluster Not Connected",
+				fmt.Sprintf("The Devzero operator did not connect to cluster %s within %s. Last observed state: %s. "+
+					"Check that the Devzero operator is installed with the cluster token and can reach the Devzero API, or %s.",
+					data.Id.ValueString(), formatDuration(timeout), describeClusterConnection(last), advice),
+			)
+			return diags
+		case <-ticker.C:
+		}
+	}
+}
+
+// isClusterConnected reports whether the Devzero operator has reported in from the cluster.
+func isClusterConnected(cluster *apiv1.Cluster) bool {
+	return cluster.IsDisconnected != nil && !*cluster.IsDisconnected && cluster.GetDakrOpInfo().GetVersion() != ""
+}
+
+// describeClusterConnection summarizes the connection state of a cluster for error messages.
+func describeClusterConnection(cluster *apiv1.Cluster) string {
+	if cluster == nil {
+		return "the cluster could not be read"
+	}
+
+	disconnected := "unknown"
+	if cluster.IsDisconnected != nil {
+		disconnected = fmt.Sprint(*cluster.IsDisconnected)
+	}
+
+	version := cluster.GetDakrOpInfo().GetVersion()
+	if version == "" {
+		version = "not reported"
+	}
+
+	return fmt.Sprintf("is_disconnected=%s, dakr_operator_version=%s", disconnected, version)
+}
+
+// unixTimestampValue formats a Unix timestamp in RFC 3339, or returns null if it is not set.
+func unixTimestampValue(seconds int64) types.String {
+	if seconds == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(time.Unix(seconds, 0).UTC().Format(time.RFC3339))
+}
+
+// operatorVersionValue returns the version of an operator, or null if it is not installed.
+func operatorVersionValue(info *apiv1.OperatorInfo) types.String {
+	if info.GetVersion() == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(info.GetVersion())
+}
+
+// applyTags adds and removes tags so that the cluster ends up with its own
+// tags merged with the provider default tags, and records the result in
+// tags_all. current holds the tags the cluster has now.
+func (r *ClusterResource) applyTags(ctx context.Context, data *ClusterResourceModel, current []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tags, err := getStringList(ctx, data.Tags.Elements())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read cluster tags, got error: %s", err))
+		return diags
+	}
+
+	desired := mergeTags(r.client.DefaultTags, tags)
+	teamId := data.TeamId.ValueString()
+
+	var remove []string
+	for _, tag := range current {
+		if !slices.Contains(desired, tag) {
+			remove = append(remove, tag)
+		}
+	}
+
+	var add []string
+	for _, tag := range desired {
+		if !slices.Contains(current, tag) {
+			add = append(add, tag)
+		}
+	}
+
+	result := current
+	if len(remove) > 0 {
+		removeTagsReq := &apiv1.RemoveClusterTagsRequest{
+			TeamId:    teamId,
+			ClusterId: data.Id.ValueString(),
+			Tags:      remove,
+		}
+		removeTagsResp, err := r.client.K8SServiceClient.RemoveClusterTags(ctx, connect.NewRequest(removeTagsReq))
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove cluster tags, got error: %s", err))
+			return diags
+		}
+		result = removeTagsResp.Msg.GetCluster().GetTags()
+	}
+
+	if len(add) > 0 {
+		addTagsReq := &apiv1.AddClusterTagsRequest{
+			TeamId:    teamId,
+			ClusterId: data.Id.ValueString(),
+			Tags:      add,
+		}
+		addTagsResp, err := r.client.K8SServiceClient.AddClusterTags(ctx, connect.NewRequest(addTagsReq))
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to add cluster tags, got error: %s", err))
+			return diags
+		}
+		result = addTagsResp.Msg.GetCluster().GetTags()
+	}
+
+	data.TagsAll = types.SetValueMust(types.StringType, fromStringList(result))
+	return diags
+}
+
+// resourceTags returns the tags of the cluster that belong to its own tags
+// attribute. Tags inherited from the provider default tags are left out,
+// unless the resource configures them as well.
+func (r *ClusterResource) resourceTags(ctx context.Context, prior types.Set, all []string) (types.Set, error) {
+	configured, err := getStringList(ctx, prior.Elements())
+	if err != nil {
+		return types.SetNull(types.StringType), err
+	}
+
+	var tags []string
+	for _, tag := range all {
+		if !slices.Contains(r.client.DefaultTags, tag) || slices.Contains(configured, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	// Keep an unset attribute unset rather than planning an empty set
+	if len(tags) == 0 && prior.IsNull() {
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueMust(types.StringType, fromStringList(tags)), nil
+}
+
+// mergeTags returns the union of the provider default tags and the resource tags, sorted.
+func mergeTags(defaultTags []string, tags []string) []string {
+	merged := append(slices.Clone(defaultTags), tags...)
+	slices.Sort(merged)
+	return slices.Compact(merged)
 }
@@ -2,10 +2,31 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
 )
 
 func TestClusterResourceSchema(t *testing.T) {
@@ -37,7 +58,7 @@ func validateClusterSchema(t *testing.T, schema schema.Schema) {
 	}
 
 	// Validate computed attributes
-	computedAttrs := []string{"id", "token"}
+	computedAttrs := []string{"id", "team_id", "token", "tags_all"}
 	for _, attr := range computedAttrs {
 		if attrSchema, exists := schema.Attributes[attr]; exists {
 			if !attrSchema.IsComputed() {
@@ -53,3 +74,1159 @@ func validateClusterSchema(t *testing.T, schema schema.Schema) {
 		}
 	}
 }
+
+func TestClusterResourceRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		getCluster func(context.Context, *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error)
+		removed    bool
+	}{
+		"not found error": {
+			getCluster: func(context.Context, *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("cluster not found"))
+			},
+			removed: true,
+		},
+		"nil cluster": {
+			getCluster: func(context.Context, *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+				return connect.NewResponse(&apiv1.GetClusterResponse{}), nil
+			},
+			removed: true,
+		},
+		"unavailable": {
+			getCluster: func(context.Context, *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+				return nil, connect.NewError(connect.CodeUnavailable, errors.New("service unavailable"))
+			},
+			removed: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &ClusterResource{client: &ClientSet{
+				TeamId:           "team-1",
+				K8SServiceClient: &stubK8SServiceClient{getCluster: tt.getCluster},
+			}}
+			req, resp := newTestReadRequest(t, r, "cluster-1")
+			r.Read(context.Background(), req, resp)
+			if tt.removed {
+				assertRemovedFromState(t, resp)
+			} else {
+				assertKeptInState(t, resp)
+			}
+		})
+	}
+}
+
+func TestClusterResourceRead_TeamID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stateTeamID    string
+		expectedTeamID string
+	}{
+		"defaults to provider team": {expectedTeamID: "team-provider"},
+		"uses resource team":        {stateTeamID: "team-resource", expectedTeamID: "team-resource"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requestTeamID string
+			r := &ClusterResource{client: &ClientSet{
+				TeamId: "team-provider",
+				K8SServiceClient: &stubK8SServiceClient{
+					getCluster: func(_ context.Context, req *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+						requestTeamID = req.Msg.TeamId
+						return connect.NewResponse(&apiv1.GetClusterResponse{
+							Cluster: &apiv1.Cluster{Id: req.Msg.ClusterId, Name: "cluster"},
+						}), nil
+					},
+				},
+			}}
+
+			req, resp := newTestReadRequest(t, r, "cluster-1")
+			if tt.stateTeamID != "" {
+				req.State.SetAttribute(context.Background(), path.Root("team_id"), tt.stateTeamID)
+			}
+			r.Read(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+
+			if requestTeamID != tt.expectedTeamID {
+				t.Errorf("Expected GetCluster for team %q, got %q", tt.expectedTeamID, requestTeamID)
+			}
+
+			var teamID types.String
+			resp.State.GetAttribute(context.Background(), path.Root("team_id"), &teamID)
+			if teamID.ValueString() != tt.expectedTeamID {
+				t.Errorf("Expected team_id %q in state, got %q", tt.expectedTeamID, teamID.ValueString())
+			}
+		})
+	}
+}
+
+func TestClusterResourceRead_Facts(t *testing.T) {
+	t.Parallel()
+
+	disconnected := false
+	r := &ClusterResource{client: &ClientSet{
+		TeamId: "team-1",
+		K8SServiceClient: &stubK8SServiceClient{
+			getCluster: func(_ context.Context, req *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+				return connect.NewResponse(&apiv1.GetClusterResponse{
+					Cluster: &apiv1.Cluster{
+						Id:                req.Msg.ClusterId,
+						Name:              "cluster",
+						DisplayName:       "Cluster",
+						CloudProvider:     "aws",
+						Region:            "us-east-1",
+						KubernetesVersion: "v1.30.2",
+						IsDisconnected:    &disconnected,
+						HasArgoWorkloads:  true,
+						CreatedAt:         1700000000,
+						DakrOpInfo:        &apiv1.OperatorInfo{Version: "v0.5.1"},
+					},
+				}), nil
+			},
+		},
+	}}
+
+	req, resp := newTestReadRequest(t, r, "cluster-1")
+	r.Read(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+	}
+
+	var data ClusterResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unable to read state: %v", resp.Diagnostics)
+	}
+
+	expected := map[string]attr.Value{
+		"display_name":          types.StringValue("Cluster"),
+		"cloud_provider":        types.StringValue("aws"),
+		"region":                types.StringValue("us-east-1"),
+		"kubernetes_version":    types.StringValue("v1.30.2"),
+		"is_disconnected":       types.BoolValue(false),
+		"has_argo_workloads":    types.BoolValue(true),
+		"created_at":            types.StringValue("2023-11-14T22:13:20Z"),
+		"updated_at":            types.StringNull(),
+		"dakr_operator_version": types.StringValue("v0.5.1"),
+		"node_operator_version": types.StringNull(),
+		"zxp_version":           types.StringNull(),
+	}
+	got := map[string]attr.Value{
+		"display_name":          data.DisplayName,
+		"cloud_provider":        data.CloudProvider,
+		"region":                data.Region,
+		"kubernetes_version":    data.KubernetesVersion,
+		"is_disconnected":       data.IsDisconnected,
+		"has_argo_workloads":    data.HasArgoWorkloads,
+		"created_at":            data.CreatedAt,
+		"updated_at":            data.UpdatedAt,
+		"dakr_operator_version": data.DakrOperatorVersion,
+		"node_operator_version": data.NodeOperatorVersion,
+		"zxp_version":           data.ZxpVersion,
+	}
+	for name, value := range expected {
+		if !got[name].Equal(value) {
+			t.Errorf("%s: expected %s, got %s", name, value, got[name])
+		}
+	}
+}
+
+func TestClusterResourceWaitForConnection(t *testing.T) {
+	t.Parallel()
+
+	connected, disconnected := false, true
+	tests := map[string]struct {
+		clusters []*apiv1.Cluster
+		timeout  string
+		deadline time.Duration
+		err      string
+	}{
+		"connects after polling": {
+			clusters: []*apiv1.Cluster{
+				{Id: "cluster-1"},
+				{Id: "cluster-1", IsDisconnected: &connected},
+				{Id: "cluster-1", IsDisconnected: &connected, DakrOpInfo: &apiv1.OperatorInfo{Version: "v0.5.1"}},
+			},
+		},
+		"times out": {
+			clusters: []*apiv1.Cluster{
+				{Id: "cluster-1", IsDisconnected: &disconnected},
+			},
+			err: "is_disconnected=true, dakr_operator_version=not reported",
+		},
+		"limited by the operation deadline": {
+			clusters: []*apiv1.Cluster{
+				{Id: "cluster-1", IsDisconnected: &disconnected},
+			},
+			timeout:  "1h",
+			deadline: waitForConnectionMargin + time.Second,
+			err:      "increase timeouts.create",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var polls int
+			r := &ClusterResource{client: &ClientSet{
+				K8SServiceClient: &stubK8SServiceClient{
+					getCluster: func(context.Context, *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+						cluster := tt.clusters[min(polls, len(tt.clusters)-1)]
+						polls++
+						return connect.NewResponse(&apiv1.GetClusterResponse{Cluster: cluster}), nil
+					},
+				},
+			}}
+
+			timeout := "200ms"
+			if tt.timeout != "" {
+				timeout = tt.timeout
+			}
+			data := &ClusterResourceModel{
+				Id:     types.StringValue("cluster-1"),
+				TeamId: types.StringValue("team-1"),
+				WaitForConnection: &WaitForConnectionModel{
+					Timeout:      types.StringValue(timeout),
+					PollInterval: types.StringValue("10ms"),
+				},
+			}
+
+			ctx := context.Background()
+			if tt.deadline > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.deadline)
+				defer cancel()
+			}
+
+			diags := r.waitForConnection(ctx, data, "create")
+			if tt.err == "" {
+				if diags.HasError() {
+					t.Fatalf("Expected no error, got %v", diags)
+				}
+				if data.DakrOperatorVersion.ValueString() != "v0.5.1" {
+					t.Errorf("Expected the connected state to be recorded, got dakr_operator_version %s", data.DakrOperatorVersion)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("Expected an error, got %v", diags)
+			}
+			if detail := diags[0].Detail(); !strings.Contains(detail, tt.err) {
+				t.Errorf("Expected error detail to contain %q, got %q", tt.err, detail)
+			}
+			if tt.deadline > 0 && ctx.Err() != nil {
+				t.Errorf("Expected the wait to end before the operation deadline, got %v", ctx.Err())
+			}
+		})
+	}
+}
+
+func TestAccClusterResource_WaitForConnection(t *testing.T) {
+	server := fakeapi.NewServer(t)
+	server.ConnectClustersAfter(2)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_cluster", func(id string) bool {
+			return server.Cluster(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "devzero_cluster" "test" {
+  name = "acc-cluster-wait"
+
+  wait_for_connection = {
+    timeout       = "1m"
+    poll_interval = "1s"
+  }
+}
+`,
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "is_disconnected", "false"),
+					tfresource.TestCheckResourceAttrSet("devzero_cluster.test", "dakr_operator_version"),
+				),
+			},
+		},
+	})
+}
+
+func TestClusterResourceModifyPlan_TokenRotation(t *testing.T) {
+	t.Parallel()
+
+	rotatedAt := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	triggers := map[string]string{"reason": "initial"}
+
+	tests := map[string]struct {
+		state  map[string]any
+		plan   map[string]any
+		rotate bool
+		err    string
+	}{
+		"unchanged": {
+			state:  map[string]any{"token": "token", "token_rotated_at": rotatedAt, "rotation_triggers": triggers},
+			plan:   map[string]any{"rotation_triggers": triggers},
+			rotate: false,
+		},
+		"empty token": {
+			state:  map[string]any{"token": ""},
+			rotate: true,
+		},
+		"triggers changed": {
+			state:  map[string]any{"token": "token", "token_rotated_at": rotatedAt, "rotation_triggers": triggers},
+			plan:   map[string]any{"rotation_triggers": map[string]string{"reason": "leaked"}},
+			rotate: true,
+		},
+		"triggers removed": {
+			state:  map[string]any{"token": "token", "token_rotated_at": rotatedAt, "rotation_triggers": triggers},
+			plan:   map[string]any{"rotation_triggers": types.MapNull(types.StringType)},
+			rotate: false,
+		},
+		"triggers added": {
+			state:  map[string]any{"token": "token", "token_rotated_at": rotatedAt},
+			plan:   map[string]any{"rotation_triggers": triggers},
+			rotate: false,
+		},
+		"rotation due": {
+			state:  map[string]any{"token": "token", "token_rotated_at": rotatedAt},
+			plan:   map[string]any{"rotate_token_after": "24h"},
+			rotate: true,
+		},
+		"rotation not due": {
+			state:  map[string]any{"token": "token", "token_rotated_at": rotatedAt},
+			plan:   map[string]any{"rotate_token_after": "72h"},
+			rotate: false,
+		},
+		"token not stored": {
+			state:  map[string]any{"token_rotated_at": rotatedAt, "store_token": false},
+			rotate: false,
+		},
+		"token not stored, rotation due": {
+			state: map[string]any{"token_rotated_at": rotatedAt, "store_token": false},
+			plan:  map[string]any{"rotate_token_after": "24h"},
+			err:   "Token Rotation Requires store_token",
+		},
+		"token not stored, triggers changed": {
+			state: map[string]any{"token_rotated_at": rotatedAt, "store_token": false, "rotation_triggers": triggers},
+			plan:  map[string]any{"rotation_triggers": map[string]string{"reason": "leaked"}},
+			err:   "Token Rotation Requires store_token",
+		},
+		"token stored again": {
+			state:  map[string]any{"token_rotated_at": rotatedAt, "store_token": false},
+			plan:   map[string]any{"store_token": true},
+			rotate: true,
+		},
+		"token no longer stored": {
+			state:  map[string]any{"token": "token", "token_rotated_at": rotatedAt},
+			plan:   map[string]any{"store_token": false},
+			rotate: false,
+		},
+	}
+
+	ctx := context.Background()
+	r := &ClusterResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			for _, attrs := range []map[string]any{{"id": "cluster-1", "name": "cluster", "store_token": true}, tt.state} {
+				for name, value := range attrs {
+					state.SetAttribute(ctx, path.Root(name), value)
+				}
+			}
+			plan.Raw = state.Raw.Copy()
+			for name, value := range tt.plan {
+				plan.SetAttribute(ctx, path.Root(name), value)
+			}
+
+			req := resource.ModifyPlanRequest{State: state, Plan: plan}
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw.Copy()}}
+			r.ModifyPlan(ctx, req, resp)
+			if tt.err != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != tt.err {
+					t.Fatalf("Expected a %q error, got %v", tt.err, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+
+			var token, rotatedAt types.String
+			var storeToken types.Bool
+			resp.Plan.GetAttribute(ctx, path.Root("token"), &token)
+			resp.Plan.GetAttribute(ctx, path.Root("token_rotated_at"), &rotatedAt)
+			resp.Plan.GetAttribute(ctx, path.Root("store_token"), &storeToken)
+			if rotatedAt.IsUnknown() != tt.rotate {
+				t.Errorf("Expected token rotation %t, got planned token_rotated_at %s", tt.rotate, rotatedAt)
+			}
+			if !storeToken.ValueBool() && !token.IsNull() {
+				t.Errorf("Expected no token to be planned when store_token is false, got %s", token)
+			}
+		})
+	}
+}
+
+func TestIsTokenRotationDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		rotatedAt   types.String
+		rotateAfter types.String
+		expected    bool
+	}{
+		"no schedule":       {rotatedAt: types.StringValue("2025-01-01T00:00:00Z"), rotateAfter: types.StringNull(), expected: false},
+		"not due":           {rotatedAt: types.StringValue("2025-01-01T00:00:00Z"), rotateAfter: types.StringValue("721h"), expected: false},
+		"due":               {rotatedAt: types.StringValue("2025-01-01T00:00:00Z"), rotateAfter: types.StringValue("720h"), expected: true},
+		"unknown rotation":  {rotatedAt: types.StringNull(), rotateAfter: types.StringValue("720h"), expected: true},
+		"invalid timestamp": {rotatedAt: types.StringValue("yesterday"), rotateAfter: types.StringValue("720h"), expected: true},
+	}
+
+	for name, tt := range tests {
+		if got := isTokenRotationDue(tt.rotatedAt, tt.rotateAfter, now); got != tt.expected {
+			t.Errorf("%s: expected %t, got %t", name, tt.expected, got)
+		}
+	}
+}
+
+func TestAccClusterResource(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_cluster", func(id string) bool {
+			return server.Cluster(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccClusterResourceConfig(server, "acc-cluster"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("devzero_cluster.test", "id"),
+					tfresource.TestCheckResourceAttrSet("devzero_cluster.test", "token"),
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "name", "acc-cluster"),
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "team_id", testAccTeamID),
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "display_name", "acc-cluster"),
+					tfresource.TestCheckResourceAttrSet("devzero_cluster.test", "created_at"),
+					tfresource.TestCheckNoResourceAttr("devzero_cluster.test", "node_operator_version"),
+					testAccCheckClusterName(server, "devzero_cluster.test", "acc-cluster"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "devzero_cluster.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The token is only returned when the cluster is created.
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			// ImportState by name testing
+			{
+				ResourceName:            "devzero_cluster.test",
+				ImportState:             true,
+				ImportStateId:           "name:acc-cluster",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccClusterResourceConfig(server, "acc-cluster-renamed"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "name", "acc-cluster-renamed"),
+					testAccCheckClusterName(server, "devzero_cluster.test", "acc-cluster-renamed"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccClusterResourceConfig(server *fakeapi.Server, name string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "devzero_cluster" "test" {
+  name = %q
+}
+`, name)
+}
+
+func TestAccClusterResource_Tags(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_cluster", func(id string) bool {
+			return server.Cluster(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccClusterResourceTagsConfig(server, `["env:dev"]`),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "tags.#", "1"),
+					tfresource.TestCheckTypeSetElemAttr("devzero_cluster.test", "tags.*", "env:dev"),
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "tags_all.#", "2"),
+					tfresource.TestCheckTypeSetElemAttr("devzero_cluster.test", "tags_all.*", "env:dev"),
+					tfresource.TestCheckTypeSetElemAttr("devzero_cluster.test", "tags_all.*", "managed-by:terraform"),
+					testAccCheckClusterTags(server, "devzero_cluster.test", "env:dev", "managed-by:terraform"),
+				),
+			},
+			{
+				ResourceName:            "devzero_cluster.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			{
+				Config: testAccClusterResourceTagsConfig(server, `["env:prod", "owner:sre"]`),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "tags.#", "2"),
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "tags_all.#", "3"),
+					testAccCheckClusterTags(server, "devzero_cluster.test", "env:prod", "managed-by:terraform", "owner:sre"),
+				),
+			},
+			{
+				Config: testAccClusterResourceTagsConfig(server, "null"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckNoResourceAttr("devzero_cluster.test", "tags.#"),
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "tags_all.#", "1"),
+					testAccCheckClusterTags(server, "devzero_cluster.test", "managed-by:terraform"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccClusterResource_RotationTriggers(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	var token string
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_cluster", func(id string) bool {
+			return server.Cluster(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccClusterResourceRotationConfig(server, "1"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("devzero_cluster.test", "token_rotated_at"),
+					tfresource.TestCheckResourceAttrWith("devzero_cluster.test", "token", func(value string) error {
+						token = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccClusterResourceRotationConfig(server, "2"),
+				Check: tfresource.TestCheckResourceAttrWith("devzero_cluster.test", "token", func(value string) error {
+					if value == token {
+						return fmt.Errorf("expected the token to be rotated")
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
+
+func testAccClusterResourceRotationConfig(server *fakeapi.Server, version string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "devzero_cluster" "test" {
+  name = "acc-cluster-rotation"
+
+  rotation_triggers = {
+    version = %q
+  }
+}
+`, version)
+}
+
+func testAccClusterResourceTagsConfig(server *fakeapi.Server, tags string) string {
+	return fmt.Sprintf(`
+provider "devzero" {
+  url          = %q
+  team_id      = %q
+  token        = "test-token"
+  protocol     = "connect"
+  default_tags = ["managed-by:terraform"]
+}
+
+resource "devzero_cluster" "test" {
+  name = "acc-cluster-tags"
+  tags = %s
+}
+`, server.URL, testAccTeamID, tags)
+}
+
+// testAccCheckClusterTags verifies the sorted cluster tags stored by the fake API server.
+func testAccCheckClusterTags(server *fakeapi.Server, resourceName string, tags ...string) tfresource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		cluster := server.Cluster(rs.Primary.ID)
+		if cluster == nil {
+			return fmt.Errorf("cluster %s not found in the API", rs.Primary.ID)
+		}
+		if !slices.Equal(cluster.Tags, tags) {
+			return fmt.Errorf("expected cluster tags %v in the API, got %v", tags, cluster.Tags)
+		}
+		return nil
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	t.Parallel()
+
+	got := mergeTags([]string{"team:platform", "env:dev"}, []string{"env:dev", "app:web"})
+	expected := []string{"app:web", "env:dev", "team:platform"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+// testAccCheckClusterName verifies the cluster name stored by the fake API server.
+func testAccCheckClusterName(server *fakeapi.Server, resourceName string, name string) tfresource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		cluster := server.Cluster(rs.Primary.ID)
+		if cluster == nil {
+			return fmt.Errorf("cluster %s not found in the API", rs.Primary.ID)
+		}
+		if cluster.CustomName != name {
+			return fmt.Errorf("expected cluster name %q in the API, got %q", name, cluster.CustomName)
+		}
+		return nil
+	}
+}
+
+func TestClusterResourceK8sProviderValidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewClusterResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	attr := schemaResp.Schema.Attributes["k8s_provider"].(schema.StringAttribute)
+
+	tests := map[string]bool{
+		"aws":   true,
+		"azure": true,
+		"gcp":   true,
+		"oci":   true,
+		"other": true,
+		"eks":   false,
+		"AWS":   false,
+	}
+
+	for value, valid := range tests {
+		resp := &validator.StringResponse{}
+		for _, v := range attr.Validators {
+			v.ValidateString(ctx, validator.StringRequest{Path: path.Root("k8s_provider"), ConfigValue: types.StringValue(value)}, resp)
+		}
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("%s: expected valid %t, got %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestClusterResourceCreateCluster_K8sProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeapi.NewServer(t)
+	server.SetTokenTeam("team-1")
+	r := &ClusterResource{client: &ClientSet{
+		TeamId:                "team-1",
+		ClusterMutationClient: apiv1connect.NewClusterMutationServiceClient(http.DefaultClient, server.URL),
+		ClusterServiceClient:  apiv1connect.NewClusterServiceClient(http.DefaultClient, server.URL),
+		K8SServiceClient:      apiv1connect.NewK8SServiceClient(http.DefaultClient, server.URL),
+	}}
+
+	tests := map[string]types.String{
+		"without provider": types.StringNull(),
+		"with provider":    types.StringValue("gcp"),
+	}
+
+	for name, k8sProvider := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := &ClusterResourceModel{Name: types.StringValue("cluster"), K8sProvider: k8sProvider}
+			cluster, token, err := r.createCluster(ctx, "team-1", data)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if cluster.GetName() != "cluster" {
+				t.Errorf("Expected the created cluster to be returned, got %v", cluster)
+			}
+			if token == "" || token != server.ClusterToken(cluster.GetId()) {
+				t.Errorf("Expected the cluster token to be returned, got %q", token)
+			}
+			if got := server.ClusterK8SProvider(cluster.GetId()); got != k8sProvider.ValueString() {
+				t.Errorf("Expected k8s_provider %q to be sent, got %q", k8sProvider.ValueString(), got)
+			}
+		})
+	}
+}
+
+func TestClusterResourceModifyPlan_K8sProviderTeam(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		teamID      any
+		k8sProvider any
+		err         bool
+	}{
+		"provider team":               {k8sProvider: "aws"},
+		"same team":                   {teamID: "team-1", k8sProvider: "aws"},
+		"other team":                  {teamID: "team-2", k8sProvider: "aws", err: true},
+		"other team without provider": {teamID: "team-2"},
+	}
+
+	ctx := context.Background()
+	r := &ClusterResource{client: &ClientSet{TeamId: "team-1"}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			plan.SetAttribute(ctx, path.Root("name"), "cluster")
+			if tt.teamID != nil {
+				plan.SetAttribute(ctx, path.Root("team_id"), tt.teamID)
+			}
+			if tt.k8sProvider != nil {
+				plan.SetAttribute(ctx, path.Root("k8s_provider"), tt.k8sProvider)
+			}
+
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+				Plan:  plan,
+			}
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw.Copy()}}
+			r.ModifyPlan(ctx, req, resp)
+			if resp.Diagnostics.HasError() != tt.err {
+				t.Errorf("Expected error %t, got %v", tt.err, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestClusterResourceModifyPlan_K8sProviderExisting(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		state   any
+		plan    any
+		warning bool
+	}{
+		"unset":             {},
+		"set on existing":   {plan: "aws", warning: true},
+		"unchanged":         {state: "aws", plan: "aws"},
+		"changed":           {state: "aws", plan: "gcp"},
+		"removed from plan": {state: "aws"},
+	}
+
+	ctx := context.Background()
+	r := &ClusterResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			for name, value := range map[string]any{"id": "cluster-1", "name": "cluster", "token": "token", "store_token": true} {
+				state.SetAttribute(ctx, path.Root(name), value)
+			}
+			if tt.state != nil {
+				state.SetAttribute(ctx, path.Root("k8s_provider"), tt.state)
+			}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw.Copy()}
+			plan.SetAttribute(ctx, path.Root("k8s_provider"), types.StringNull())
+			if tt.plan != nil {
+				plan.SetAttribute(ctx, path.Root("k8s_provider"), tt.plan)
+			}
+
+			req := resource.ModifyPlanRequest{State: state, Plan: plan}
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw.Copy()}}
+			r.ModifyPlan(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tt.warning {
+				t.Errorf("Expected warning %t, got %v", tt.warning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAccClusterResource_K8sProvider(t *testing.T) {
+	server := fakeapi.NewServer(t)
+	server.SetTokenTeam(testAccTeamID)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_cluster", func(id string) bool {
+			return server.Cluster(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			{
+				Config:      testAccClusterResourceK8sProviderConfig(server, "eks"),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: testAccClusterResourceK8sProviderConfig(server, "gcp"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_cluster.test", "k8s_provider", "gcp"),
+					tfresource.TestCheckResourceAttrSet("devzero_cluster.test", "token"),
+					tfresource.TestCheckResourceAttrSet("devzero_cluster.test", "created_at"),
+					func(s *terraform.State) error {
+						id := s.RootModule().Resources["devzero_cluster.test"].Primary.ID
+						if got := server.ClusterK8SProvider(id); got != "gcp" {
+							return fmt.Errorf("expected the cluster to be created with k8s_provider gcp, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:             testAccClusterResourceK8sProviderConfig(server, "aws"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccClusterResourceK8sProviderConfig(server *fakeapi.Server, k8sProvider string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "devzero_cluster" "test" {
+  name         = "acc-cluster-k8s-provider"
+  k8s_provider = %q
+}
+`, k8sProvider)
+}
+
+func TestClusterResourceDelete_RemoveOptimizations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeapi.NewServer(t)
+	client := &ClientSet{
+		TeamId:                "team-1",
+		ClusterMutationClient: apiv1connect.NewClusterMutationServiceClient(http.DefaultClient, server.URL),
+		RecommendationClient:  apiv1connect.NewK8SRecommendationServiceClient(http.DefaultClient, server.URL),
+	}
+
+	tests := map[string]bool{
+		"remove optimizations": true,
+		"keep optimizations":   false,
+	}
+
+	for name, remove := range tests {
+		t.Run(name, func(t *testing.T) {
+			createClusterResp, err := client.ClusterMutationClient.CreateCluster(ctx, connect.NewRequest(&apiv1.CreateClusterRequest{TeamId: "team-1", ClusterName: "cluster"}))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			clusterID := createClusterResp.Msg.Cluster.Id
+
+			createPoliciesResp, err := client.RecommendationClient.CreateNodePolicies(ctx, connect.NewRequest(&apiv1.CreateNodePoliciesRequest{
+				TeamId:   "team-1",
+				Policies: []*apiv1.NodePolicy{{Name: "policy"}},
+			}))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			createTargetsResp, err := client.RecommendationClient.CreateNodePolicyTargets(ctx, connect.NewRequest(&apiv1.CreateNodePolicyTargetsRequest{
+				Targets: []*apiv1.NodePolicyTarget{{
+					TeamId:     "team-1",
+					PolicyId:   createPoliciesResp.Msg.Policies[0].Id,
+					Name:       "target",
+					ClusterIds: []string{clusterID},
+					Enabled:    true,
+				}},
+			}))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			targetID := createTargetsResp.Msg.Targets[0].TargetId
+
+			r := &ClusterResource{client: client}
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			state.SetAttribute(ctx, path.Root("id"), clusterID)
+			state.SetAttribute(ctx, path.Root("remove_optimizations_on_destroy"), remove)
+
+			resp := &resource.DeleteResponse{State: state}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+
+			if server.Cluster(clusterID) != nil {
+				t.Error("Expected the cluster to be deleted")
+			}
+			if enabled := server.NodePolicyTarget(targetID).Enabled; enabled == remove {
+				t.Errorf("Expected node policy target enabled %t, got %t", !remove, enabled)
+			}
+		})
+	}
+}
+
+func TestClusterResourceCreate_PartialFailure(t *testing.T) {
+	t.Parallel()
+
+	unavailable := func() (connect.AnyResponse, error) {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("service unavailable"))
+	}
+
+	tests := map[string]struct {
+		attrs    map[string]any
+		override func(server *fakeapi.Server) []connect.ClientOption
+		warning  string
+	}{
+		"tagging fails": {
+			attrs: map[string]any{"tags": []string{"env:prod"}},
+			override: func(*fakeapi.Server) []connect.ClientOption {
+				return []connect.ClientOption{overrideProcedure(apiv1connect.K8SServiceAddClusterTagsProcedure, unavailable)}
+			},
+			warning: "Cluster Tags Not Applied",
+		},
+		"read fails after CreateClusterToken": {
+			attrs: map[string]any{"k8s_provider": "gcp"},
+			override: func(*fakeapi.Server) []connect.ClientOption {
+				return []connect.ClientOption{overrideProcedure(apiv1connect.K8SServiceGetClusterProcedure, unavailable)}
+			},
+			warning: "Cluster Not Read",
+		},
+		"read returns no cluster after CreateClusterToken": {
+			attrs: map[string]any{"k8s_provider": "gcp"},
+			override: func(*fakeapi.Server) []connect.ClientOption {
+				return []connect.ClientOption{overrideProcedure(apiv1connect.K8SServiceGetClusterProcedure, func() (connect.AnyResponse, error) {
+					return connect.NewResponse(&apiv1.GetClusterResponse{}), nil
+				})}
+			},
+			warning: "Cluster Not Read",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			server := fakeapi.NewServer(t)
+			server.SetTokenTeam("team-1")
+			r := &ClusterResource{client: &ClientSet{
+				TeamId:                "team-1",
+				ClusterMutationClient: apiv1connect.NewClusterMutationServiceClient(http.DefaultClient, server.URL),
+				ClusterServiceClient:  apiv1connect.NewClusterServiceClient(http.DefaultClient, server.URL),
+				K8SServiceClient:      apiv1connect.NewK8SServiceClient(http.DefaultClient, server.URL, tt.override(server)...),
+			}}
+
+			attrs := map[string]any{"name": "cluster", "store_token": true}
+			maps.Copy(attrs, tt.attrs)
+			req, resp := newTestCreateRequest(t, r, attrs)
+			r.Create(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error once the cluster exists, got %v", resp.Diagnostics)
+			}
+			if warnings := resp.Diagnostics.Warnings(); len(warnings) == 0 || warnings[0].Summary() != tt.warning {
+				t.Errorf("Expected a %q warning, got %v", tt.warning, resp.Diagnostics)
+			}
+
+			var id, token types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			resp.State.GetAttribute(ctx, path.Root("token"), &token)
+			if server.Cluster(id.ValueString()) == nil {
+				t.Errorf("Expected the created cluster to be saved in state, got id %s", id)
+			}
+			if token.ValueString() == "" || token.ValueString() != server.ClusterToken(id.ValueString()) {
+				t.Errorf("Expected the cluster token to be saved in state, got %s", token)
+			}
+		})
+	}
+}
+
+func TestClusterResourceCreate_WaitForConnection(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeapi.NewServer(t)
+	r := &ClusterResource{client: &ClientSet{
+		TeamId:                "team-1",
+		ClusterMutationClient: apiv1connect.NewClusterMutationServiceClient(http.DefaultClient, server.URL),
+		K8SServiceClient:      apiv1connect.NewK8SServiceClient(http.DefaultClient, server.URL),
+	}}
+
+	req, resp := newTestCreateRequest(t, r, map[string]any{
+		"name":        "cluster",
+		"store_token": true,
+		"wait_for_connection": &WaitForConnectionModel{
+			Timeout:      types.StringValue("50ms"),
+			PollInterval: types.StringValue("10ms"),
+		},
+	})
+	r.Create(ctx, req, resp)
+
+	// The operator never connects to the fake API server
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Cluster Not Connected" {
+		t.Fatalf("Expected a %q error, got %v", "Cluster Not Connected", resp.Diagnostics)
+	}
+
+	var id, token types.String
+	resp.State.GetAttribute(ctx, path.Root("id"), &id)
+	resp.State.GetAttribute(ctx, path.Root("token"), &token)
+	if server.Cluster(id.ValueString()) == nil {
+		t.Errorf("Expected the created cluster to be saved in state, got id %s", id)
+	}
+	if token.ValueString() == "" || token.ValueString() != server.ClusterToken(id.ValueString()) {
+		t.Errorf("Expected the cluster token to be saved in state, got %s", token)
+	}
+}
+
+func TestClusterResourceUpdate_WaitForConnection(t *testing.T) {
+	t.Parallel()
+
+	server := fakeapi.NewServer(t)
+	r := &ClusterResource{client: &ClientSet{
+		TeamId:                "team-1",
+		ClusterMutationClient: apiv1connect.NewClusterMutationServiceClient(http.DefaultClient, server.URL),
+		K8SServiceClient:      apiv1connect.NewK8SServiceClient(http.DefaultClient, server.URL),
+	}}
+
+	createClusterResp, err := r.client.ClusterMutationClient.CreateCluster(context.Background(), connect.NewRequest(&apiv1.CreateClusterRequest{TeamId: "team-1", ClusterName: "cluster"}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := map[string]struct {
+		tokenRotatedAt any
+		wait           bool
+	}{
+		"token unchanged": {tokenRotatedAt: "2026-01-01T00:00:00Z", wait: false},
+		"token rotated":   {tokenRotatedAt: types.StringUnknown(), wait: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			for name, value := range map[string]any{
+				"id":               createClusterResp.Msg.Cluster.Id,
+				"team_id":          "team-1",
+				"name":             "cluster",
+				"token":            createClusterResp.Msg.Token,
+				"store_token":      true,
+				"token_rotated_at": "2026-01-01T00:00:00Z",
+				"wait_for_connection": &WaitForConnectionModel{
+					Timeout:      types.StringValue("100ms"),
+					PollInterval: types.StringValue("10ms"),
+				},
+			} {
+				state.SetAttribute(ctx, path.Root(name), value)
+			}
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+			plan.SetAttribute(ctx, path.Root("token_rotated_at"), tt.tokenRotatedAt)
+
+			req := resource.UpdateRequest{State: state, Plan: plan}
+			resp := &resource.UpdateResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+			r.Update(ctx, req, resp)
+
+			// The operator never connects to the fake API server, so waiting ends in an error
+			if waited := resp.Diagnostics.HasError(); waited != tt.wait {
+				t.Errorf("Expected waiting for the operator %t, got %v", tt.wait, resp.Diagnostics)
+			}
+			// The subtests share the cluster, so only a rotation is checked against its current token
+			var token types.String
+			resp.State.GetAttribute(ctx, path.Root("token"), &token)
+			if tt.wait && token.ValueString() != server.ClusterToken(createClusterResp.Msg.Cluster.Id) {
+				t.Errorf("Expected the new cluster token to be saved in state, got %s", token)
+			}
+		})
+	}
+}
+
+func TestClusterResourceLookupIDByName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeapi.NewServer(t)
+	r := &ClusterResource{client: &ClientSet{
+		TeamId:                "team-1",
+		ClusterMutationClient: apiv1connect.NewClusterMutationServiceClient(http.DefaultClient, server.URL),
+		ClusterServiceClient:  apiv1connect.NewClusterServiceClient(http.DefaultClient, server.URL),
+	}}
+
+	clusterIDs := map[string]string{}
+	for _, cluster := range []struct{ team, name string }{
+		{"team-1", "cluster"},
+		{"team-2", "cluster"},
+		{"team-1", "twin"},
+		{"team-1", "twin"},
+	} {
+		createClusterResp, err := r.client.ClusterMutationClient.CreateCluster(ctx, connect.NewRequest(&apiv1.CreateClusterRequest{
+			TeamId:      cluster.team,
+			ClusterName: cluster.name,
+		}))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		clusterIDs[cluster.team+"/"+cluster.name] = createClusterResp.Msg.Cluster.Id
+	}
+
+	tests := map[string]struct {
+		teamID      string
+		name        string
+		expectedID  string
+		expectError string
+	}{
+		"found":          {teamID: "team-1", name: "cluster", expectedID: clusterIDs["team-1/cluster"]},
+		"other team":     {teamID: "team-2", name: "cluster", expectedID: clusterIDs["team-2/cluster"]},
+		"not found":      {teamID: "team-2", name: "twin", expectError: `no cluster named "twin" found in team "team-2"`},
+		"ambiguous name": {teamID: "team-1", name: "twin", expectError: `more than one cluster is named "twin" in team "team-1"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := r.lookupIDByName(ctx, tt.teamID, tt.name)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if id != tt.expectedID {
+				t.Errorf("Expected id %q, got %q", tt.expectedID, id)
+			}
+		})
+	}
+}
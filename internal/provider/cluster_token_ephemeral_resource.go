@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ClusterTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ClusterTokenEphemeralResource{}
+
+func NewClusterTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &ClusterTokenEphemeralResource{}
+}
+
+type ClusterTokenEphemeralResource struct {
+	client *ClientSet
+}
+
+type ClusterTokenEphemeralResourceModel struct {
+	ClusterId types.String `tfsdk:"cluster_id"`
+	TeamId    types.String `tfsdk:"team_id"`
+	Rotate    types.Bool   `tfsdk:"rotate"`
+	Token     types.String `tfsdk:"token"`
+}
+
+func (r *ClusterTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_token"
+}
+
+func (r *ClusterTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Issues a new token for an existing cluster without storing it in the Terraform state or plan, " +
+			"e.g. to pass it to a write-only attribute of the resource installing the Devzero operator. Pair it with `store_token = false` on `devzero_cluster`.\n\n" +
+			"The API can only issue a token by resetting the cluster token, which invalidates the previous one, and Terraform opens ephemeral resources on every plan and apply. " +
+			"The token is therefore only reset when `rotate` is `true`: set it, e.g. with `-var rotate_token=true`, for the run that installs the operator " +
+			"or rotates its token, and leave it `false` otherwise.\n\n" +
+			"~> **Note** Every plan and apply with `rotate = true` issues a new token, so `terraform apply` without a saved plan resets the token twice: " +
+			"only the token of the apply is valid, which is the one passed to the consumer during the apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the cluster to issue a token for",
+				Required:            true,
+			},
+			"team_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the Devzero team that owns the cluster. Defaults to the provider `team_id`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"rotate": schema.BoolAttribute{
+				MarkdownDescription: "Whether to reset the cluster token and return the new one. Defaults to `false`, in which case `token` is null and the current token stays valid.",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "New token of the cluster. Null unless `rotate` is `true`.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *ClusterTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ClientSet, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ClusterTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+	data.Token = types.StringNull()
+
+	if !data.Rotate.ValueBool() {
+		tflog.Debug(ctx, "Not issuing a cluster token, as rotate is not set", map[string]any{
+			"cluster_id": data.ClusterId.ValueString(),
+		})
+		resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+		return
+	}
+
+	resetReq := &apiv1.ResetClusterTokenRequest{
+		TeamId:    teamId,
+		ClusterId: data.ClusterId.ValueString(),
+	}
+
+	resetResp, err := r.client.ClusterMutationClient.ResetClusterToken(ctx, connect.NewRequest(resetReq))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset cluster token, got error: %s", err))
+		return
+	}
+	if resetResp.Msg.Token == "" {
+		resp.Diagnostics.AddError("Client Error", "Cluster token reset returned empty token")
+		return
+	}
+
+	data.Token = types.StringValue(resetResp.Msg.Token)
+
+	tflog.Trace(ctx, "issued a cluster token", map[string]any{
+		"cluster_id": data.ClusterId.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
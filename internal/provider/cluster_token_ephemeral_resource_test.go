@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
+)
+
+func TestClusterTokenEphemeralResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &ephemeral.SchemaResponse{}
+	NewClusterTokenEphemeralResource().Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResp.Diagnostics)
+	}
+
+	if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diags)
+	}
+}
+
+func TestClusterTokenEphemeralResourceOpen(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rotate any
+		reset  bool
+	}{
+		"rotate": {
+			rotate: true,
+			reset:  true,
+		},
+		"rotate false": {
+			rotate: false,
+		},
+		"rotate unset": {},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			server := fakeapi.NewServer(t)
+			client := apiv1connect.NewClusterMutationServiceClient(http.DefaultClient, server.URL)
+
+			createResp, err := client.CreateCluster(ctx, connect.NewRequest(&apiv1.CreateClusterRequest{
+				TeamId:      "team-1",
+				ClusterName: "cluster",
+			}))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			clusterID := createResp.Msg.Cluster.Id
+
+			r := &ClusterTokenEphemeralResource{client: &ClientSet{TeamId: "team-1", ClusterMutationClient: client}}
+			schemaResp := &ephemeral.SchemaResponse{}
+			r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			// tfsdk.Config has no setter, so build the raw value through a state
+			state := tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+			state.SetAttribute(ctx, path.Root("cluster_id"), clusterID)
+			if tt.rotate != nil {
+				state.SetAttribute(ctx, path.Root("rotate"), tt.rotate)
+			}
+			config.Raw = state.Raw
+
+			resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: config.Raw.Copy()}}
+			r.Open(ctx, ephemeral.OpenRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+
+			var data ClusterTokenEphemeralResourceModel
+			resp.Diagnostics.Append(resp.Result.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unable to read result: %v", resp.Diagnostics)
+			}
+
+			current := server.ClusterToken(clusterID)
+			if reset := current != createResp.Msg.Token; reset != tt.reset {
+				t.Errorf("Expected cluster token reset %t, got %t", tt.reset, reset)
+			}
+			if tt.reset && data.Token.ValueString() != current {
+				t.Errorf("Expected token %q, got %q", current, data.Token.ValueString())
+			}
+			if !tt.reset && !data.Token.IsNull() {
+				t.Errorf("Expected a null token, got %s", data.Token)
+			}
+			if data.TeamId != types.StringValue("team-1") {
+				t.Errorf("Expected team_id to default to the provider team, got %s", data.TeamId)
+			}
+		})
+	}
+}
+
+func TestAccClusterTokenEphemeralResource(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"devzero": testAccProtoV6ProviderFactories["devzero"],
+			"echo":    echoprovider.NewProviderServer(),
+		},
+		CheckDestroy: testAccCheckDestroyed("devzero_cluster", func(id string) bool {
+			return server.Cluster(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "devzero_cluster" "test" {
+  name        = "acc-cluster-token"
+  store_token = false
+}
+
+ephemeral "devzero_cluster_token" "test" {
+  cluster_id = devzero_cluster.test.id
+  rotate     = true
+}
+
+provider "echo" {
+  data = ephemeral.devzero_cluster_token.test.token
+}
+
+resource "echo" "token" {}
+`,
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckNoResourceAttr("devzero_cluster.test", "token"),
+					testAccCheckClusterToken(server, "devzero_cluster.test", "echo.token"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckClusterToken verifies that the token echoed from the ephemeral
+// resource is the current cluster token of the fake API server.
+func testAccCheckClusterToken(server *fakeapi.Server, clusterName, echoName string) tfresource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		cluster, ok := s.RootModule().Resources[clusterName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", clusterName)
+		}
+		echo, ok := s.RootModule().Resources[echoName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", echoName)
+		}
+		if token := server.ClusterToken(cluster.Primary.ID); echo.Primary.Attributes["data"] != token {
+			return fmt.Errorf("expected the echoed token to be the cluster token %q, got %q", token, echo.Primary.Attributes["data"])
+		}
+		return nil
+	}
+}
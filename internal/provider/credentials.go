@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultProfileName = "default"
+
+// credentials holds the settings used to connect to the Devzero API.
+type credentials struct {
+	url    string
+	teamID string
+	token  string
+}
+
+// or fills the empty settings of c from fallback.
+func (c credentials) or(fallback credentials) credentials {
+	if c.url == "" {
+		c.url = fallback.url
+	}
+	if c.teamID == "" {
+		c.teamID = fallback.teamID
+	}
+	if c.token == "" {
+		c.token = fallback.token
+	}
+	return c
+}
+
+// credentialsError is an error resolving the credentials, attributed to the
+// provider attribute that caused it.
+type credentialsError struct {
+	attribute string
+	err       error
+}
+
+func (e *credentialsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *credentialsError) Unwrap() error {
+	return e.err
+}
+
+// resolveCredentials resolves the connection settings. Each setting comes
+// from, in order of precedence, the provider configuration, the DEVZERO_URL,
+// DEVZERO_TEAM_ID and DEVZERO_TOKEN environment variables, and the profile
+// of the shared credentials file named with profile or DEVZERO_PROFILE, or
+// its default profile.
+func resolveCredentials(data DevzeroProviderModel) (credentials, error) {
+	args := credentials{
+		url:    data.URL.ValueString(),
+		teamID: data.TeamId.ValueString(),
+		token:  data.Token.ValueString(),
+	}
+
+	env := credentials{
+		url:    os.Getenv("DEVZERO_URL"),
+		teamID: os.Getenv("DEVZERO_TEAM_ID"),
+		token:  os.Getenv("DEVZERO_TOKEN"),
+	}
+
+	profile, err := loadProfile(stringValueOrEnv(data.Profile, "DEVZERO_PROFILE"), stringValueOrEnv(data.SharedConfigFile, "DEVZERO_SHARED_CONFIG_FILE"))
+	if err != nil {
+		return credentials{}, err
+	}
+
+	return args.or(env).or(profile), nil
+}
+
+// loadProfile reads a profile from the shared credentials file, which
+// defaults to ~/.devzero/credentials. A missing file or profile is only an
+// error when the profile or file was explicitly requested. Errors are
+// attributed to the profile or shared_config_file attribute.
+func loadProfile(name, filename string) (credentials, error) {
+	explicit := name != "" || filename != ""
+	if name == "" {
+		name = defaultProfileName
+	}
+
+	fileAttribute := "shared_config_file"
+	if filename == "" {
+		fileAttribute = "profile"
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return credentials{}, nil
+		}
+		filename = filepath.Join(home, ".devzero", "credentials")
+	} else if strings.HasPrefix(filename, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return credentials{}, &credentialsError{fileAttribute, fmt.Errorf("unable to expand %q: %w", filename, err)}
+		}
+		filename = filepath.Join(home, filename[2:])
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			return credentials{}, nil
+		}
+		return credentials{}, &credentialsError{fileAttribute, fmt.Errorf("unable to read shared credentials file: %w", err)}
+	}
+	defer f.Close()
+
+	profiles, err := parseCredentialsFile(f)
+	if err != nil {
+		return credentials{}, &credentialsError{fileAttribute, fmt.Errorf("unable to parse shared credentials file %s: %w", filename, err)}
+	}
+
+	values, ok := profiles[name]
+	if !ok {
+		if !explicit {
+			return credentials{}, nil
+		}
+		return credentials{}, &credentialsError{"profile", fmt.Errorf("profile %q not found in shared credentials file %s", name, filename)}
+	}
+
+	return credentials{
+		url:    values["url"],
+		teamID: values["team_id"],
+		token:  values["token"],
+	}, nil
+}
+
+// parseCredentialsFile parses an INI-style credentials file:
+//
+//	[default]
+//	team_id = <team id>
+//	token   = <token>
+//
+//	[staging]
+//	url = https://dakr.staging.example.com
+//
+// Blank lines and lines starting with '#' or ';' are ignored.
+func parseCredentialsFile(r io.Reader) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid section header %q", lineNumber, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+			current = map[string]string{}
+			profiles[name] = current
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected \"key = value\"", lineNumber)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: %q is outside of a profile section", lineNumber, strings.TrimSpace(key))
+		}
+		current[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
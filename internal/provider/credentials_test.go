@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testCredentialsFile = `# Devzero credentials
+[default]
+team_id = team-default
+token   = token-default
+
+[staging]
+url     = "https://dakr.staging.example.com"
+team_id = team-staging
+token   = token-staging
+
+; Self-hosted control plane
+[self-hosted]
+url = https://dakr.internal.example.com
+`
+
+func TestParseCredentialsFile(t *testing.T) {
+	t.Parallel()
+
+	profiles, err := parseCredentialsFile(strings.NewReader(testCredentialsFile))
+	if err != nil {
+		t.Fatalf("Failed to parse credentials file: %v", err)
+	}
+
+	if len(profiles) != 3 {
+		t.Fatalf("Expected 3 profiles, got %d", len(profiles))
+	}
+	if got := profiles["default"]["token"]; got != "token-default" {
+		t.Errorf("Expected default token token-default, got %q", got)
+	}
+	if got := profiles["staging"]["url"]; got != "https://dakr.staging.example.com" {
+		t.Errorf("Expected quotes to be stripped from staging url, got %q", got)
+	}
+	if got := profiles["self-hosted"]["url"]; got != "https://dakr.internal.example.com" {
+		t.Errorf("Expected self-hosted url, got %q", got)
+	}
+}
+
+func TestParseCredentialsFile_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"unterminated section": "[default\ntoken = x\n",
+		"empty section":        "[]\ntoken = x\n",
+		"missing equals":       "[default]\ntoken\n",
+		"key outside section":  "token = x\n[default]\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := parseCredentialsFile(strings.NewReader(content)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".devzero"), 0o700); err != nil {
+		t.Fatalf("Failed to create credentials directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".devzero", "credentials"), []byte(testCredentialsFile), 0o600); err != nil {
+		t.Fatalf("Failed to write credentials file: %v", err)
+	}
+
+	customFile := writeTestFile(t, "credentials", "[custom]\nteam_id = team-custom\ntoken = token-custom\n")
+
+	tests := map[string]struct {
+		data     DevzeroProviderModel
+		env      map[string]string
+		expected credentials
+	}{
+		"default profile": {
+			expected: credentials{teamID: "team-default", token: "token-default"},
+		},
+		"profile attribute": {
+			data:     DevzeroProviderModel{Profile: types.StringValue("staging")},
+			expected: credentials{url: "https://dakr.staging.example.com", teamID: "team-staging", token: "token-staging"},
+		},
+		"profile environment variable": {
+			env:      map[string]string{"DEVZERO_PROFILE": "staging"},
+			expected: credentials{url: "https://dakr.staging.example.com", teamID: "team-staging", token: "token-staging"},
+		},
+		"profile attribute overrides environment variable": {
+			data:     DevzeroProviderModel{Profile: types.StringValue("self-hosted")},
+			env:      map[string]string{"DEVZERO_PROFILE": "staging"},
+			expected: credentials{url: "https://dakr.internal.example.com"},
+		},
+		"environment variables override profile attribute": {
+			data:     DevzeroProviderModel{Profile: types.StringValue("staging")},
+			env:      map[string]string{"DEVZERO_TEAM_ID": "team-env", "DEVZERO_TOKEN": "token-env"},
+			expected: credentials{url: "https://dakr.staging.example.com", teamID: "team-env", token: "token-env"},
+		},
+		"environment variables override profile environment variable": {
+			env:      map[string]string{"DEVZERO_PROFILE": "self-hosted", "DEVZERO_TOKEN": "token-env"},
+			expected: credentials{url: "https://dakr.internal.example.com", token: "token-env"},
+		},
+		"environment variables override default profile": {
+			env:      map[string]string{"DEVZERO_URL": "https://dakr.example.com", "DEVZERO_TOKEN": "token-env"},
+			expected: credentials{url: "https://dakr.example.com", teamID: "team-default", token: "token-env"},
+		},
+		"environment variables without profile": {
+			env:      map[string]string{"DEVZERO_URL": "https://dakr.example.com", "DEVZERO_TEAM_ID": "team-env", "DEVZERO_TOKEN": "token-env"},
+			expected: credentials{url: "https://dakr.example.com", teamID: "team-env", token: "token-env"},
+		},
+		"arguments override default profile": {
+			data:     DevzeroProviderModel{Token: types.StringValue("token-arg")},
+			expected: credentials{teamID: "team-default", token: "token-arg"},
+		},
+		"arguments override profile": {
+			data: DevzeroProviderModel{
+				Profile: types.StringValue("staging"),
+				URL:     types.StringValue("https://dakr.example.com"),
+				TeamId:  types.StringValue("team-arg"),
+			},
+			env:      map[string]string{"DEVZERO_TEAM_ID": "team-env"},
+			expected: credentials{url: "https://dakr.example.com", teamID: "team-arg", token: "token-staging"},
+		},
+		"shared_config_file attribute": {
+			data:     DevzeroProviderModel{Profile: types.StringValue("custom"), SharedConfigFile: types.StringValue(customFile)},
+			expected: credentials{teamID: "team-custom", token: "token-custom"},
+		},
+		"shared_config_file environment variable": {
+			env:      map[string]string{"DEVZERO_PROFILE": "custom", "DEVZERO_SHARED_CONFIG_FILE": customFile},
+			expected: credentials{teamID: "team-custom", token: "token-custom"},
+		},
+		"shared_config_file relative to home": {
+			data:     DevzeroProviderModel{Profile: types.StringValue("staging"), SharedConfigFile: types.StringValue("~/.devzero/credentials")},
+			expected: credentials{url: "https://dakr.staging.example.com", teamID: "team-staging", token: "token-staging"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"DEVZERO_URL", "DEVZERO_TEAM_ID", "DEVZERO_TOKEN", "DEVZERO_PROFILE", "DEVZERO_SHARED_CONFIG_FILE"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			creds, err := resolveCredentials(tt.data)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if creds != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, creds)
+			}
+		})
+	}
+}
+
+func TestResolveCredentials_Errors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, key := range []string{"DEVZERO_URL", "DEVZERO_TEAM_ID", "DEVZERO_TOKEN", "DEVZERO_PROFILE", "DEVZERO_SHARED_CONFIG_FILE"} {
+		t.Setenv(key, "")
+	}
+
+	credentialsFile := writeTestFile(t, "credentials", testCredentialsFile)
+
+	// Without an explicit profile a missing default file is not an error.
+	creds, err := resolveCredentials(DevzeroProviderModel{})
+	if err != nil {
+		t.Fatalf("Expected no error without a credentials file, got %v", err)
+	}
+	if creds != (credentials{}) {
+		t.Errorf("Expected empty credentials, got %+v", creds)
+	}
+
+	tests := map[string]struct {
+		data      DevzeroProviderModel
+		attribute string
+	}{
+		"explicit profile without file": {
+			data:      DevzeroProviderModel{Profile: types.StringValue("staging")},
+			attribute: "profile",
+		},
+		"missing shared_config_file": {
+			data:      DevzeroProviderModel{SharedConfigFile: types.StringValue(filepath.Join(t.TempDir(), "missing"))},
+			attribute: "shared_config_file",
+		},
+		"invalid shared_config_file": {
+			data:      DevzeroProviderModel{SharedConfigFile: types.StringValue(writeTestFile(t, "invalid", "token = x\n"))},
+			attribute: "shared_config_file",
+		},
+		"unknown profile": {
+			data:      DevzeroProviderModel{Profile: types.StringValue("production"), SharedConfigFile: types.StringValue(credentialsFile)},
+			attribute: "profile",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := resolveCredentials(tt.data)
+			var credsErr *credentialsError
+			if !errors.As(err, &credsErr) {
+				t.Fatalf("Expected a credentials error, got %v", err)
+			}
+			if credsErr.attribute != tt.attribute {
+				t.Errorf("Expected the error to be attributed to %s, got %s", tt.attribute, credsErr.attribute)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the deletion_protection attribute of a
+// resource of the given kind.
+func deletionProtectionAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("Whether Terraform is prevented from destroying or replacing the %s. "+
+			"It must be set to `false` and applied before the %s can be destroyed. Defaults to `false`.", kind, kind),
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// checkDeletionProtection fails plans that destroy or replace a resource whose
+// prior state has deletion_protection set. The check uses the prior state, so
+// that disabling the protection only takes effect once applied.
+func checkDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, kind string) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var protected types.Bool
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() || !protected.ValueBool() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		addDeletionProtectionError(&resp.Diagnostics, kind, id.ValueString())
+		return
+	}
+
+	replaced := requiresReplace(ctx, req, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, p := range append(replaced, resp.RequiresReplace...) {
+		resp.Diagnostics.AddAttributeError(
+			p,
+			"Deletion Protection Enabled",
+			fmt.Sprintf("Changing %s replaces the %s %s, which has deletion_protection set to true. "+
+				"Set deletion_protection to false and apply before replacing it.", p, kind, id.ValueString()),
+		)
+	}
+}
+
+// requiresReplace returns the string attributes whose schema plan modifiers
+// force a new resource for the planned change. The framework runs these
+// modifiers before the resource ModifyPlan but does not pass their result to
+// it, so they are run again here.
+func requiresReplace(ctx context.Context, req resource.ModifyPlanRequest, diags *diag.Diagnostics) path.Paths {
+	var paths path.Paths
+	for name, attribute := range req.Plan.Schema.GetAttributes() {
+		stringAttribute, ok := attribute.(schema.StringAttribute)
+		if !ok || len(stringAttribute.PlanModifiers) == 0 {
+			continue
+		}
+
+		modifyReq := planmodifier.StringRequest{
+			Path:   path.Root(name),
+			Config: req.Config,
+			Plan:   req.Plan,
+			State:  req.State,
+		}
+		diags.Append(req.State.GetAttribute(ctx, modifyReq.Path, &modifyReq.StateValue)...)
+		diags.Append(req.Plan.GetAttribute(ctx, modifyReq.Path, &modifyReq.PlanValue)...)
+		if !req.Config.Raw.IsNull() {
+			diags.Append(req.Config.GetAttribute(ctx, modifyReq.Path, &modifyReq.ConfigValue)...)
+		}
+		if diags.HasError() {
+			return nil
+		}
+
+		for _, modifier := range stringAttribute.PlanModifiers {
+			modifyResp := &planmodifier.StringResponse{PlanValue: modifyReq.PlanValue}
+			modifier.PlanModifyString(ctx, modifyReq, modifyResp)
+			if modifyResp.RequiresReplace {
+				paths = append(paths, modifyReq.Path)
+				break
+			}
+		}
+	}
+	return paths
+}
+
+// addDeletionProtectionError reports that a protected resource cannot be destroyed.
+func addDeletionProtectionError(diags *diag.Diagnostics, kind string, id string) {
+	diags.AddError(
+		"Deletion Protection Enabled",
+		fmt.Sprintf("The %s %s has deletion_protection set to true and cannot be destroyed. "+
+			"Set deletion_protection to false and apply before destroying it.", kind, id),
+	)
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
+)
+
+func TestCheckDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]any{"id": "cluster-1", "name": "cluster", "team_id": "team-1", "deletion_protection": true}
+	tests := map[string]struct {
+		state           map[string]any
+		plan            map[string]any
+		requiresReplace path.Paths
+		destroy         bool
+		create          bool
+		err             string
+	}{
+		"create": {
+			create: true,
+		},
+		"update": {
+			plan: map[string]any{"name": "renamed"},
+		},
+		"destroy": {
+			destroy: true,
+			err:     "cannot be destroyed",
+		},
+		"destroy unprotected": {
+			state:   map[string]any{"deletion_protection": false},
+			destroy: true,
+		},
+		"disable protection": {
+			plan: map[string]any{"deletion_protection": false},
+		},
+		"replace": {
+			plan: map[string]any{"team_id": "team-2"},
+			err:  "Changing team_id replaces",
+		},
+		"replace unprotected": {
+			state: map[string]any{"deletion_protection": false},
+			plan:  map[string]any{"team_id": "team-2", "deletion_protection": false},
+		},
+		"replace while disabling protection": {
+			plan: map[string]any{"team_id": "team-2", "deletion_protection": false},
+			err:  "Changing team_id replaces",
+		},
+		"set k8s_provider after import": {
+			plan: map[string]any{"k8s_provider": "aws"},
+		},
+		"change k8s_provider": {
+			state: map[string]any{"k8s_provider": "aws"},
+			plan:  map[string]any{"k8s_provider": "gcp"},
+			err:   "Changing k8s_provider replaces",
+		},
+		"replace requested by the resource": {
+			plan:            map[string]any{"name": "renamed"},
+			requiresReplace: path.Paths{path.Root("name")},
+			err:             "Changing name replaces",
+		},
+	}
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewClusterResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	nullValue := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: nullValue}
+			if !tt.create {
+				for _, attrs := range []map[string]any{base, tt.state} {
+					for name, value := range attrs {
+						state.SetAttribute(ctx, path.Root(name), value)
+					}
+				}
+			}
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: nullValue}
+			if !tt.destroy {
+				plan.Raw = state.Raw.Copy()
+				if tt.create {
+					plan.SetAttribute(ctx, path.Root("name"), "cluster")
+				}
+				for name, value := range tt.plan {
+					plan.SetAttribute(ctx, path.Root(name), value)
+				}
+			}
+			if tt.create {
+				state.Raw = nullValue
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan, RequiresReplace: tt.requiresReplace}
+			checkDeletionProtection(ctx, resource.ModifyPlanRequest{State: state, Plan: plan}, resp, "cluster")
+			if tt.err == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("Expected an error")
+			}
+			if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, tt.err) {
+				t.Errorf("Expected error detail to contain %q, got %q", tt.err, detail)
+			}
+		})
+	}
+}
+
+func TestResourcesDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	resources := map[string]resource.Resource{
+		"devzero_cluster":         &ClusterResource{client: &ClientSet{}},
+		"devzero_workload_policy": &WorkloadPolicyResource{client: &ClientSet{}},
+		"devzero_node_policy":     &NodePolicyResource{client: &ClientSet{}},
+	}
+
+	for name, r := range resources {
+		t.Run(name, func(t *testing.T) {
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			if _, ok := r.(resource.ResourceWithModifyPlan); !ok {
+				t.Error("Expected the resource to check deletion_protection in ModifyPlan")
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			state.SetAttribute(ctx, path.Root("id"), "resource-1")
+			state.SetAttribute(ctx, path.Root("deletion_protection"), true)
+
+			// The clients are nil, so reaching the API would panic
+			resp := &resource.DeleteResponse{State: state}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("Expected deletion to be refused")
+			}
+			if summary := resp.Diagnostics[0].Summary(); summary != "Deletion Protection Enabled" {
+				t.Errorf("Expected a deletion protection error, got %q", summary)
+			}
+		})
+	}
+}
+
+func TestAccClusterResource_DeletionProtection(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_cluster", func(id string) bool {
+			return server.Cluster(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccClusterResourceDeletionProtectionConfig(server, true),
+				Check:  tfresource.TestCheckResourceAttr("devzero_cluster.test", "deletion_protection", "true"),
+			},
+			{
+				Config:      testAccClusterResourceDeletionProtectionConfig(server, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			{
+				Config: testAccClusterResourceDeletionProtectionConfig(server, false),
+				Check:  tfresource.TestCheckResourceAttr("devzero_cluster.test", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func testAccClusterResourceDeletionProtectionConfig(server *fakeapi.Server, protected bool) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "devzero_cluster" "test" {
+  name                = "acc-cluster-protected"
+  deletion_protection = %t
+}
+`, protected)
+}
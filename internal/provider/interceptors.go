@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultMaxRetries      = 3
+	defaultRetryMinBackoff = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// newAuthInterceptor attaches the API token to every request.
+func newAuthInterceptor(token string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			return next(ctx, req)
+		}
+	}
+}
+
+// userAgent builds the User-Agent sent with every request, e.g.
+// "terraform-provider-devzero/1.2.0 terraform/1.9.5 ci/deploy-prod".
+func userAgent(providerVersion, terraformVersion, suffix string) string {
+	ua := fmt.Sprintf("terraform-provider-devzero/%s terraform/%s", providerVersion, terraformVersion)
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
+
+// newMetadataInterceptor sets the User-Agent and a unique X-Request-Id on
+// every call. It runs before retries, so all attempts of a call share the
+// same request ID.
+func newMetadataInterceptor(userAgent string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set("User-Agent", userAgent)
+			if requestID, err := uuid.GenerateUUID(); err == nil {
+				req.Header().Set("X-Request-Id", requestID)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// sensitiveLogFields are header and message fields whose values are never logged.
+var sensitiveLogFields = []string{"authorization", "token", "user_token", "user_data"}
+
+// bearerTokenPattern matches bearer tokens echoed back in error messages.
+var bearerTokenPattern = regexp.MustCompile(`Bearer\s+\S+`)
+
+// newLoggingInterceptor logs every RPC with its duration and status code at
+// DEBUG, and the protojson-encoded request and response bodies at TRACE.
+func newLoggingInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogFields...)
+			ctx = tflog.MaskAllFieldValuesRegexes(ctx, bearerTokenPattern)
+
+			procedure := req.Spec().Procedure
+			requestID := req.Header().Get("X-Request-Id")
+			tflog.Trace(ctx, "Sending Devzero API request", map[string]any{
+				"procedure":     procedure,
+				"request_id":    requestID,
+				"authorization": req.Header().Get("Authorization"),
+				"request_body":  protoLogValue(req.Any()),
+			})
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+			fields := map[string]any{
+				"procedure":  procedure,
+				"request_id": requestID,
+				"duration":   time.Since(start).String(),
+			}
+
+			if err != nil {
+				fields["code"] = connect.CodeOf(err).String()
+				fields["error"] = err.Error()
+				tflog.Debug(ctx, "Devzero API request failed", fields)
+				return resp, err
+			}
+
+			fields["code"] = "ok"
+			tflog.Debug(ctx, "Devzero API request completed", fields)
+			tflog.Trace(ctx, "Received Devzero API response", map[string]any{
+				"procedure":     procedure,
+				"request_id":    requestID,
+				"response_body": protoLogValue(resp.Any()),
+			})
+
+			return resp, nil
+		}
+	}
+}
+
+// protoLogValue encodes msg as JSON for logging, masking sensitive fields at
+// any depth.
+func protoLogValue(msg any) string {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return fmt.Sprintf("%T", msg)
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return fmt.Sprintf("<unable to encode %T: %s>", msg, err)
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Sprintf("<unable to encode %T: %s>", msg, err)
+	}
+
+	data, err = json.Marshal(maskSensitiveFields(value))
+	if err != nil {
+		return fmt.Sprintf("<unable to encode %T: %s>", msg, err)
+	}
+	return string(data)
+}
+
+// maskSensitiveFields replaces the values of sensitiveLogFields in decoded JSON.
+func maskSensitiveFields(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if isSensitiveLogField(key) {
+				v[key] = "***"
+				continue
+			}
+			v[key] = maskSensitiveFields(field)
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = maskSensitiveFields(elem)
+		}
+	}
+	return value
+}
+
+func isSensitiveLogField(key string) bool {
+	for _, sensitive := range sensitiveLogFields {
+		if key == sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// retryPolicy controls how idempotent RPCs are retried on transient errors.
+type retryPolicy struct {
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// backoff returns the delay before the given retry attempt (starting at 0).
+// The delay grows exponentially from minBackoff, is capped at maxBackoff, and
+// is jittered between half and the full value so that parallel resources do
+// not retry in lockstep.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.minBackoff
+	for i := 0; i < attempt && delay < p.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.maxBackoff {
+		delay = p.maxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// newRetryInterceptor retries idempotent RPCs that fail with a transient error.
+func newRetryInterceptor(policy retryPolicy) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			procedure := req.Spec().Procedure
+			if !isIdempotentProcedure(procedure) {
+				return next(ctx, req)
+			}
+
+			for attempt := 0; ; attempt++ {
+				resp, err := next(ctx, req)
+				if err == nil || attempt >= policy.maxRetries || !isRetryableError(err) {
+					return resp, err
+				}
+
+				delay := policy.backoff(attempt)
+				tflog.Warn(ctx, "Devzero API request failed with a transient error, retrying", map[string]any{
+					"procedure":   procedure,
+					"code":        connect.CodeOf(err).String(),
+					"error":       err.Error(),
+					"attempt":     attempt + 1,
+					"max_retries": policy.maxRetries,
+					"backoff":     delay.String(),
+				})
+
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return resp, err
+				case <-timer.C:
+				}
+			}
+		}
+	}
+}
+
+// isIdempotentProcedure reports whether a procedure such as
+// "/api.v1.K8SService/GetCluster" only reads data and is safe to retry.
+func isIdempotentProcedure(procedure string) bool {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	return strings.HasPrefix(method, "Get") || strings.HasPrefix(method, "List")
+}
+
+// isRetryableError reports whether err is a transient error worth retrying.
+func isRetryableError(err error) bool {
+	if errors.Is(err, errRateLimited) {
+		return false
+	}
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeResourceExhausted, connect.CodeDeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
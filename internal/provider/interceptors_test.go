@@ -0,0 +1,383 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
+)
+
+// flakyK8SServiceHandler fails the first `failures` calls with `code`.
+type flakyK8SServiceHandler struct {
+	apiv1connect.UnimplementedK8SServiceHandler
+	failures int32
+	code     connect.Code
+	calls    atomic.Int32
+}
+
+func (h *flakyK8SServiceHandler) GetCluster(ctx context.Context, req *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+	if h.calls.Add(1) <= h.failures {
+		return nil, connect.NewError(h.code, errors.New("transient failure"))
+	}
+	return connect.NewResponse(&apiv1.GetClusterResponse{
+		Cluster: &apiv1.Cluster{Id: req.Msg.ClusterId},
+	}), nil
+}
+
+func (h *flakyK8SServiceHandler) AddClusterTags(ctx context.Context, req *connect.Request[apiv1.AddClusterTagsRequest]) (*connect.Response[apiv1.AddClusterTagsResponse], error) {
+	h.calls.Add(1)
+	return nil, connect.NewError(h.code, errors.New("transient failure"))
+}
+
+func newFlakyK8SServiceClient(t *testing.T, handler *flakyK8SServiceHandler, policy retryPolicy) apiv1connect.K8SServiceClient {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewK8SServiceHandler(handler))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return apiv1connect.NewK8SServiceClient(
+		server.Client(),
+		server.URL,
+		connect.WithInterceptors(newRetryInterceptor(policy)),
+	)
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	policy := retryPolicy{maxRetries: 3, minBackoff: time.Millisecond, maxBackoff: 5 * time.Millisecond}
+
+	tests := map[string]struct {
+		failures      int32
+		code          connect.Code
+		expectedCalls int32
+		expectError   bool
+	}{
+		"succeeds after transient failures": {failures: 2, code: connect.CodeUnavailable, expectedCalls: 3},
+		"retries resource exhausted":        {failures: 1, code: connect.CodeResourceExhausted, expectedCalls: 2},
+		"retries deadline exceeded":         {failures: 1, code: connect.CodeDeadlineExceeded, expectedCalls: 2},
+		"gives up after max retries":        {failures: 10, code: connect.CodeUnavailable, expectedCalls: 4, expectError: true},
+		"does not retry not found":          {failures: 10, code: connect.CodeNotFound, expectedCalls: 1, expectError: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := &flakyK8SServiceHandler{failures: tt.failures, code: tt.code}
+			client := newFlakyK8SServiceClient(t, handler, policy)
+
+			_, err := client.GetCluster(context.Background(), connect.NewRequest(&apiv1.GetClusterRequest{ClusterId: "cluster-1"}))
+			if tt.expectError && err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !tt.expectError && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if tt.expectError && connect.CodeOf(err) != tt.code {
+				t.Errorf("Expected code %s, got %s", tt.code, connect.CodeOf(err))
+			}
+			if got := handler.calls.Load(); got != tt.expectedCalls {
+				t.Errorf("Expected %d calls, got %d", tt.expectedCalls, got)
+			}
+		})
+	}
+}
+
+func TestRetryInterceptor_SkipsMutations(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyK8SServiceHandler{failures: 10, code: connect.CodeUnavailable}
+	client := newFlakyK8SServiceClient(t, handler, retryPolicy{maxRetries: 3, minBackoff: time.Millisecond, maxBackoff: time.Millisecond})
+
+	_, err := client.AddClusterTags(context.Background(), connect.NewRequest(&apiv1.AddClusterTagsRequest{ClusterId: "cluster-1"}))
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if got := handler.calls.Load(); got != 1 {
+		t.Errorf("Expected mutation to be attempted once, got %d calls", got)
+	}
+}
+
+func TestRetryInterceptor_StopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyK8SServiceHandler{failures: 10, code: connect.CodeUnavailable}
+	client := newFlakyK8SServiceClient(t, handler, retryPolicy{maxRetries: 5, minBackoff: time.Hour, maxBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetCluster(ctx, connect.NewRequest(&apiv1.GetClusterRequest{ClusterId: "cluster-1"}))
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if got := handler.calls.Load(); got != 1 {
+		t.Errorf("Expected 1 call before the context expired, got %d", got)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	policy := retryPolicy{maxRetries: 10, minBackoff: 100 * time.Millisecond, maxBackoff: time.Second}
+
+	for attempt, ceiling := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		for i := 0; i < 20; i++ {
+			delay := policy.backoff(attempt)
+			if delay < ceiling/2 || delay > ceiling {
+				t.Fatalf("attempt %d: expected delay in [%s, %s], got %s", attempt, ceiling/2, ceiling, delay)
+			}
+		}
+	}
+
+	if delay := (retryPolicy{}).backoff(3); delay != 0 {
+		t.Errorf("Expected zero backoff to stay zero, got %s", delay)
+	}
+}
+
+func TestIsIdempotentProcedure(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		apiv1connect.K8SServiceGetClusterProcedure:                                    true,
+		apiv1connect.K8SRecommendationServiceListNodePoliciesProcedure:                true,
+		apiv1connect.K8SRecommendationServiceGetWorkloadRecommendationPolicyProcedure: true,
+		apiv1connect.ClusterMutationServiceCreateClusterProcedure:                     false,
+		apiv1connect.K8SRecommendationServiceDeleteNodePolicyProcedure:                false,
+		apiv1connect.K8SRecommendationServiceUpdateNodePolicyProcedure:                false,
+	}
+
+	for procedure, expected := range tests {
+		if got := isIdempotentProcedure(procedure); got != expected {
+			t.Errorf("%s: expected %t, got %t", procedure, expected, got)
+		}
+	}
+}
+
+// clusterMutationHandler returns a cluster token from CreateCluster.
+type clusterMutationHandler struct {
+	apiv1connect.UnimplementedClusterMutationServiceHandler
+}
+
+func (h *clusterMutationHandler) CreateCluster(ctx context.Context, req *connect.Request[apiv1.CreateClusterRequest]) (*connect.Response[apiv1.CreateClusterResponse], error) {
+	return connect.NewResponse(&apiv1.CreateClusterResponse{
+		Cluster: &apiv1.Cluster{Id: "cluster-1", Name: req.Msg.ClusterName},
+		Token:   "secret-cluster-token",
+	}), nil
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewClusterMutationServiceHandler(&clusterMutationHandler{}))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := apiv1connect.NewClusterMutationServiceClient(
+		server.Client(),
+		server.URL,
+		connect.WithInterceptors(newAuthInterceptor("secret-api-token"), newLoggingInterceptor()),
+	)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err := client.CreateCluster(ctx, connect.NewRequest(&apiv1.CreateClusterRequest{TeamId: "team-1", ClusterName: "my-cluster"}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Contains(output.String(), "secret-api-token") {
+		t.Error("Expected the API token to be masked")
+	}
+	if strings.Contains(output.String(), "secret-cluster-token") {
+		t.Error("Expected the cluster token to be masked")
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Failed to decode log output: %v", err)
+	}
+
+	messages := map[string]map[string]any{}
+	for _, entry := range entries {
+		message, _ := entry["@message"].(string)
+		messages[message] = entry
+	}
+
+	sent, ok := messages["Sending Devzero API request"]
+	if !ok {
+		t.Fatalf("Expected a request log entry, got %v", entries)
+	}
+	if sent["@level"] != "trace" || sent["procedure"] != apiv1connect.ClusterMutationServiceCreateClusterProcedure {
+		t.Errorf("Unexpected request log entry: %v", sent)
+	}
+	if sent["authorization"] != "***" {
+		t.Errorf("Expected authorization to be masked, got %v", sent["authorization"])
+	}
+	if body, _ := sent["request_body"].(string); !strings.Contains(body, `"cluster_name":"my-cluster"`) {
+		t.Errorf("Expected request body to be logged, got %v", sent["request_body"])
+	}
+
+	completed, ok := messages["Devzero API request completed"]
+	if !ok {
+		t.Fatalf("Expected a completion log entry, got %v", entries)
+	}
+	if completed["@level"] != "debug" || completed["code"] != "ok" || completed["duration"] == nil {
+		t.Errorf("Unexpected completion log entry: %v", completed)
+	}
+
+	received, ok := messages["Received Devzero API response"]
+	if !ok {
+		t.Fatalf("Expected a response log entry, got %v", entries)
+	}
+	if body, _ := received["response_body"].(string); !strings.Contains(body, `"token":"***"`) {
+		t.Errorf("Expected token to be masked in response body, got %v", received["response_body"])
+	}
+}
+
+func TestLoggingInterceptor_Error(t *testing.T) {
+	t.Parallel()
+
+	handler := &flakyK8SServiceHandler{failures: 1, code: connect.CodeNotFound}
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewK8SServiceHandler(handler))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := apiv1connect.NewK8SServiceClient(server.Client(), server.URL, connect.WithInterceptors(newLoggingInterceptor()))
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	if _, err := client.GetCluster(ctx, connect.NewRequest(&apiv1.GetClusterRequest{ClusterId: "cluster-1"})); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Failed to decode log output: %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry["@message"] == "Devzero API request failed" {
+			if entry["code"] != "not_found" || entry["@level"] != "debug" {
+				t.Errorf("Unexpected failure log entry: %v", entry)
+			}
+			return
+		}
+	}
+	t.Errorf("Expected a failure log entry, got %v", entries)
+}
+
+func TestProtoLogValue_MasksNestedFields(t *testing.T) {
+	t.Parallel()
+
+	userData := "#!/bin/bash\necho secret"
+	value := protoLogValue(&apiv1.NodePolicy{
+		Name: "policy",
+		Aws:  &apiv1.AWSNodeClassSpec{UserData: &userData},
+	})
+
+	if strings.Contains(value, "echo secret") {
+		t.Errorf("Expected user_data to be masked, got %s", value)
+	}
+	if !strings.Contains(value, `"user_data":"***"`) || !strings.Contains(value, `"name":"policy"`) {
+		t.Errorf("Unexpected encoded value: %s", value)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		suffix   string
+		expected string
+	}{
+		"without suffix": {expected: "terraform-provider-devzero/1.2.0 terraform/1.9.5"},
+		"with suffix":    {suffix: "ci/deploy-prod", expected: "terraform-provider-devzero/1.2.0 terraform/1.9.5 ci/deploy-prod"},
+		"blank suffix":   {suffix: "  ", expected: "terraform-provider-devzero/1.2.0 terraform/1.9.5"},
+	}
+
+	for name, tt := range tests {
+		if got := userAgent("1.2.0", "1.9.5", tt.suffix); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", name, tt.expected, got)
+		}
+	}
+}
+
+func TestMetadataInterceptor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		headers []http.Header
+	)
+	handler := &flakyK8SServiceHandler{failures: 1, code: connect.CodeUnavailable}
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewK8SServiceHandler(handler))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client := apiv1connect.NewK8SServiceClient(
+		server.Client(),
+		server.URL,
+		connect.WithInterceptors(
+			newMetadataInterceptor("terraform-provider-devzero/test terraform/1.9.5"),
+			newRetryInterceptor(retryPolicy{maxRetries: 1, minBackoff: time.Millisecond, maxBackoff: time.Millisecond}),
+		),
+	)
+
+	// The first call is retried once, the second call succeeds immediately.
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetCluster(context.Background(), connect.NewRequest(&apiv1.GetClusterRequest{ClusterId: "cluster-1"})); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if len(headers) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(headers))
+	}
+	for _, h := range headers {
+		if got := h.Get("User-Agent"); got != "terraform-provider-devzero/test terraform/1.9.5" {
+			t.Errorf("Unexpected User-Agent %q", got)
+		}
+		if h.Get("X-Request-Id") == "" {
+			t.Error("Expected X-Request-Id to be set")
+		}
+	}
+	if headers[0].Get("X-Request-Id") != headers[1].Get("X-Request-Id") {
+		t.Error("Expected retries to reuse the request ID")
+	}
+	if headers[1].Get("X-Request-Id") == headers[2].Get("X-Request-Id") {
+		t.Error("Expected each call to get a new request ID")
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
+)
+
+// defaultListCacheTTL is how long a cached list response is reused.
+const defaultListCacheTTL = time.Minute
+
+// cachedListProcedures are the List* RPCs whose responses are cached. Their
+// requests only carry a team ID, so a response can be reused by every
+// resource of the team.
+var cachedListProcedures = map[string]bool{
+	apiv1connect.K8SRecommendationServiceListNodePoliciesProcedure:      true,
+	apiv1connect.K8SRecommendationServiceListNodePolicyTargetsProcedure: true,
+}
+
+// listCache caches list responses per team and RPC, so that refreshing many
+// resources that are only readable through a List* RPC costs one call per RPC
+// rather than one per resource. Any mutation invalidates the entries of its
+// team. A TTL of zero disables the cache.
+type listCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[listCacheKey]*listCacheEntry
+}
+
+type listCacheKey struct {
+	procedure string
+	teamID    string
+}
+
+// listCacheEntry is a list response, possibly still being fetched. resp and
+// err must only be read once ready is closed.
+type listCacheEntry struct {
+	ready   chan struct{}
+	resp    connect.AnyResponse
+	err     error
+	expires time.Time
+}
+
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[listCacheKey]*listCacheEntry),
+	}
+}
+
+// interceptor serves cached list responses and invalidates the cache after
+// every mutation. Cached responses are shared between callers and must not be
+// modified.
+func (c *listCache) interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if c.ttl <= 0 {
+				return next(ctx, req)
+			}
+
+			procedure := req.Spec().Procedure
+			teamID := requestTeamID(req)
+
+			if cachedListProcedures[procedure] {
+				return c.get(ctx, listCacheKey{procedure: procedure, teamID: teamID}, func() (connect.AnyResponse, error) {
+					return next(ctx, req)
+				})
+			}
+
+			if !isIdempotentProcedure(procedure) {
+				// Invalidate even when the mutation fails, as it may have been partially applied.
+				defer c.invalidate(teamID)
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// get returns the cached response for key, calling fetch on a miss.
+// Concurrent misses share a single call.
+func (c *listCache) get(ctx context.Context, key listCacheKey, fetch func() (connect.AnyResponse, error)) (connect.AnyResponse, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && (e.expires.IsZero() || c.now().Before(e.expires)) {
+		c.mu.Unlock()
+
+		select {
+		case <-e.ready:
+		case <-ctx.Done():
+			return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+		}
+
+		// The shared call may have failed because of the caller's context, so try again with ours.
+		if e.err != nil {
+			return fetch()
+		}
+
+		tflog.Debug(ctx, "Using cached Devzero API response", map[string]any{
+			"procedure": key.procedure,
+			"team_id":   key.teamID,
+		})
+		return e.resp, nil
+	}
+
+	e := &listCacheEntry{ready: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	e.resp, e.err = fetch()
+
+	c.mu.Lock()
+	if e.err != nil {
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+	} else {
+		e.expires = c.now().Add(c.ttl)
+	}
+	c.mu.Unlock()
+	close(e.ready)
+
+	return e.resp, e.err
+}
+
+// invalidate drops the cached responses of a team, or of every team if
+// teamID is empty.
+func (c *listCache) invalidate(teamID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if teamID == "" || key.teamID == teamID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// requestTeamID returns the team ID of a request, or an empty string if the
+// request is not scoped to a team.
+func requestTeamID(req connect.AnyRequest) string {
+	if msg, ok := req.Any().(interface{ GetTeamId() string }); ok {
+		return msg.GetTeamId()
+	}
+	return ""
+}
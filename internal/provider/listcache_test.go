@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
+)
+
+// newListCacheTestClient returns a client that goes through cache and
+// counts the list calls that reach the server.
+func newListCacheTestClient(t *testing.T, cache *listCache) (apiv1connect.K8SRecommendationServiceClient, *atomic.Int32) {
+	t.Helper()
+
+	server := fakeapi.NewServer(t)
+
+	var calls atomic.Int32
+	counter := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if cachedListProcedures[req.Spec().Procedure] {
+				calls.Add(1)
+			}
+			return next(ctx, req)
+		}
+	})
+
+	client := apiv1connect.NewK8SRecommendationServiceClient(http.DefaultClient, server.URL, connect.WithInterceptors(cache.interceptor(), counter))
+	return client, &calls
+}
+
+func listNodePolicies(t *testing.T, client apiv1connect.K8SRecommendationServiceClient, teamID string) []*apiv1.NodePolicy {
+	t.Helper()
+
+	resp, err := client.ListNodePolicies(context.Background(), connect.NewRequest(&apiv1.ListNodePoliciesRequest{TeamId: teamID}))
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+		return nil
+	}
+	return resp.Msg.Policies
+}
+
+func TestListCache(t *testing.T) {
+	t.Parallel()
+
+	client, calls := newListCacheTestClient(t, newListCache(time.Minute))
+
+	listNodePolicies(t, client, "team-1")
+	listNodePolicies(t, client, "team-1")
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected 1 list call, got %d", got)
+	}
+
+	listNodePolicies(t, client, "team-2")
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected a separate list call for another team, got %d calls", got)
+	}
+
+	_, err := client.CreateNodePolicies(context.Background(), connect.NewRequest(&apiv1.CreateNodePoliciesRequest{
+		TeamId:   "team-1",
+		Policies: []*apiv1.NodePolicy{{Name: "policy-1"}},
+	}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if policies := listNodePolicies(t, client, "team-1"); len(policies) != 1 {
+		t.Errorf("Expected the created policy to be listed, got %d policies", len(policies))
+	}
+	listNodePolicies(t, client, "team-2")
+	if got := calls.Load(); got != 3 {
+		t.Errorf("Expected only the mutated team to be invalidated, got %d calls", got)
+	}
+}
+
+func TestListCache_TTL(t *testing.T) {
+	t.Parallel()
+
+	cache := newListCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	client, calls := newListCacheTestClient(t, cache)
+
+	listNodePolicies(t, client, "team-1")
+	now = now.Add(2 * time.Minute)
+	listNodePolicies(t, client, "team-1")
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected an expired entry to be fetched again, got %d calls", got)
+	}
+}
+
+func TestListCache_Disabled(t *testing.T) {
+	t.Parallel()
+
+	client, calls := newListCacheTestClient(t, newListCache(0))
+
+	listNodePolicies(t, client, "team-1")
+	listNodePolicies(t, client, "team-1")
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected every list call to reach the server, got %d calls", got)
+	}
+}
+
+func TestListCache_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	client, calls := newListCacheTestClient(t, newListCache(time.Minute))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			listNodePolicies(t, client, "team-1")
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected concurrent reads to share 1 list call, got %d", got)
+	}
+}
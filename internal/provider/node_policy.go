@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -24,9 +26,10 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &NodePolicyResource{}
-	_ resource.ResourceWithConfigure   = &NodePolicyResource{}
-	_ resource.ResourceWithImportState = &NodePolicyResource{}
+	_ resource.Resource                     = &NodePolicyResource{}
+	_ resource.ResourceWithConfigure        = &NodePolicyResource{}
+	_ resource.ResourceWithImportState      = &NodePolicyResource{}
+	_ resource.ResourceWithConfigValidators = &NodePolicyResource{}
 )
 
 func NewNodePolicyResource() resource.Resource {
@@ -41,6 +44,7 @@ type NodePolicyResource struct {
 // NodePolicyResourceModel describes the resource data model.
 type NodePolicyResourceModel struct {
 	Id                     types.String      `tfsdk:"id"`
+	TeamId                 types.String      `tfsdk:"team_id"`
 	Name                   types.String      `tfsdk:"name"`
 	Description            types.String      `tfsdk:"description"`
 	Weight                 types.Int32       `tfsdk:"weight"`
@@ -51,12 +55,14 @@ type NodePolicyResourceModel struct {
 	InstanceGenerations    *LabelSelector    `tfsdk:"instance_generations"`
 	InstanceSizes          *LabelSelector    `tfsdk:"instance_sizes"`
 	InstanceTypes          *LabelSelector    `tfsdk:"instance_types"`
+	InstanceLocalNvme      *LabelSelector    `tfsdk:"instance_local_nvme"`
 	InstanceCategoriesTip  types.String      `tfsdk:"instance_categories_tip"`
 	InstanceFamiliesTip    types.String      `tfsdk:"instance_families_tip"`
 	InstanceCpusTip        types.String      `tfsdk:"instance_cpus_tip"`
 	InstanceHypervisorsTip types.String      `tfsdk:"instance_hypervisors_tip"`
 	InstanceGenerationsTip types.String      `tfsdk:"instance_generations_tip"`
 	InstanceSizesTip       types.String      `tfsdk:"instance_sizes_tip"`
+	InstanceLocalNvmeTip   types.String      `tfsdk:"instance_local_nvme_tip"`
 	Zones                  *LabelSelector    `tfsdk:"zones"`
 	Architectures          *LabelSelector    `tfsdk:"architectures"`
 	CapacityTypes          *LabelSelector    `tfsdk:"capacity_types"`
@@ -66,10 +72,13 @@ type NodePolicyResourceModel struct {
 	CapacityTypeTip        types.String      `tfsdk:"capacity_type_tip"`
 	OperatingSystemsTip    types.String      `tfsdk:"operating_systems_tip"`
 	Labels                 types.Map         `tfsdk:"labels"`
-	Taints                 types.List        `tfsdk:"taints"` // List of Taint objects
+	Taints                 types.List        `tfsdk:"taints"`         // List of Taint objects
+	StartupTaints          types.List        `tfsdk:"startup_taints"` // List of Taint objects
 	Disruption             *DisruptionPolicy `tfsdk:"disruption"`
 	Limits                 *ResourceLimits   `tfsdk:"limits"`
+	ZonalShift             *ZonalShiftConfig `tfsdk:"zonal_shift"`
 	TaintsTip              types.String      `tfsdk:"taints_tip"`
+	StartupTaintsTip       types.String      `tfsdk:"startup_taints_tip"`
 	DisruptionsTip         types.String      `tfsdk:"disruptions_tip"`
 	LimitsTip              types.String      `tfsdk:"limits_tip"`
 	MasterOverrideRoleName types.String      `tfsdk:"master_override_role_name"`
@@ -77,7 +86,20 @@ type NodePolicyResourceModel struct {
 	NodeClassName          types.String      `tfsdk:"node_class_name"`
 	Aws                    *AWSNodeClass     `tfsdk:"aws"`
 	Azure                  *AzureNodeClass   `tfsdk:"azure"`
+	Gcp                    *GCPNodeClass     `tfsdk:"gcp"`
+	Oci                    *OCINodeClass     `tfsdk:"oci"`
 	Raw                    types.List        `tfsdk:"raw"` // List of RawKarpenterSpec objects
+	RetainOnDestroy        types.Bool        `tfsdk:"retain_on_destroy"`
+	DeletionProtection     types.Bool        `tfsdk:"deletion_protection"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ZonalShiftConfig defines availability zone outage handling.
+type ZonalShiftConfig struct {
+	RespectZonalShift  types.Bool `tfsdk:"respect_zonal_shift"`
+	EvictImpactedNodes types.Bool `tfsdk:"evict_impacted_nodes"`
+	AllowZoneFallback  types.Bool `tfsdk:"allow_zone_fallback"`
 }
 
 // Taint defines Kubernetes taints.
@@ -180,6 +202,51 @@ type AzureNodeClass struct {
 	MaxPods      types.Int32                `tfsdk:"max_pods"`
 }
 
+// GCPNodeClass defines GCP-specific node configuration.
+type GCPNodeClass struct {
+	ServiceAccount       types.String          `tfsdk:"service_account"`
+	ImageSelectorTerms   types.List            `tfsdk:"image_selector_terms"`
+	ImageFamily          types.String          `tfsdk:"image_family"`
+	KubeletConfiguration *KubeletConfiguration `tfsdk:"kubelet_configuration"`
+	Labels               types.Map             `tfsdk:"labels"`
+	Metadata             types.Map             `tfsdk:"metadata"`
+	NetworkTags          types.List            `tfsdk:"network_tags"`
+	Disks                types.List            `tfsdk:"disks"`
+}
+
+// OCINodeClass defines OCI-specific node configuration.
+type OCINodeClass struct {
+	VcnId                 types.String      `tfsdk:"vcn_id"`
+	ImageSelector         types.List        `tfsdk:"image_selector"`
+	SubnetSelector        types.List        `tfsdk:"subnet_selector"`
+	SecurityGroupSelector types.List        `tfsdk:"security_group_selector"`
+	UserData              types.String      `tfsdk:"user_data"`
+	PreInstallScript      types.String      `tfsdk:"pre_install_script"`
+	MetaData              types.Map         `tfsdk:"meta_data"`
+	ImageFamily           types.String      `tfsdk:"image_family"`
+	Tags                  types.Map         `tfsdk:"tags"`
+	FreeFormTags          types.Map         `tfsdk:"free_form_tags"`
+	BootConfig            *OCIBootConfig    `tfsdk:"boot_config"`
+	LaunchOptions         *OCILaunchOptions `tfsdk:"launch_options"`
+	BlockDevices          types.List        `tfsdk:"block_devices"`
+	AgentList             types.List        `tfsdk:"agent_list"`
+}
+
+// OCIBootConfig defines the boot volume configuration for OCI instances.
+type OCIBootConfig struct {
+	BootVolumeSizeInGbs types.Int64 `tfsdk:"boot_volume_size_in_gbs"`
+	BootVolumeVpusPerGb types.Int64 `tfsdk:"boot_volume_vpus_per_gb"`
+}
+
+// OCILaunchOptions defines launch options for OCI instances.
+type OCILaunchOptions struct {
+	BootVolumeType                  types.String `tfsdk:"boot_volume_type"`
+	Firmware                        types.String `tfsdk:"firmware"`
+	NetworkType                     types.String `tfsdk:"network_type"`
+	RemoteDataVolumeType            types.String `tfsdk:"remote_data_volume_type"`
+	IsConsistentVolumeNamingEnabled types.Bool   `tfsdk:"is_consistent_volume_naming_enabled"`
+}
+
 // RawKarpenterSpec defines raw Karpenter YAML specs.
 type RawKarpenterSpec struct {
 	NodepoolYaml  types.String `tfsdk:"nodepool_yaml"`
@@ -192,7 +259,10 @@ func (r *NodePolicyResource) Metadata(ctx context.Context, req resource.Metadata
 
 func (r *NodePolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages DevZero node policies for Kubernetes cluster node provisioning and optimization using Karpenter.",
+		MarkdownDescription: "Manages DevZero node policies for Kubernetes cluster node provisioning and optimization using Karpenter.\n\n" +
+			"Cloud-specific node class settings go in at most one of the `aws`, `azure`, `gcp` or `oci` blocks.\n\n" +
+			"Destroying this resource deletes the policy in DevZero, together with any node policy targets attached to it. " +
+			"Policies left behind by earlier provider versions, whose destroy only removed them from state, can be cleaned up by importing them and running `terraform destroy`.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -203,6 +273,7 @@ func (r *NodePolicyResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"team_id": teamIDAttribute(),
 			"name": schema.StringAttribute{
 				Description:         "Human-friendly name for the policy",
 				MarkdownDescription: "Human-friendly name for the policy. Used for display in the DevZero UI.",
@@ -230,6 +301,7 @@ func (r *NodePolicyResource) Schema(ctx context.Context, req resource.SchemaRequ
 			"instance_generations": labelSelectorAttribute("Instance generations selector (e.g., 4 for Azure, 5 for AWS)"),
 			"instance_sizes":       labelSelectorAttribute("Instance sizes selector (e.g., Standard_D4s for Azure, large for AWS)"),
 			"instance_types":       labelSelectorAttribute("Instance types selector — explicit full type names (e.g., m5.xlarge for AWS, Standard_D4s_v2 for Azure)"),
+			"instance_local_nvme":  labelSelectorAttribute("Instance local NVMe storage selector in GiB (AWS only)"),
 			// Tooltip fields for instance selectors
 			"instance_categories_tip":  tooltipAttribute("Tooltip for instance categories"),
 			"instance_families_tip":    tooltipAttribute("Tooltip for instance families"),
@@ -237,6 +309,7 @@ func (r *NodePolicyResource) Schema(ctx context.Context, req resource.SchemaRequ
 			"instance_hypervisors_tip": tooltipAttribute("Tooltip for instance hypervisors"),
 			"instance_generations_tip": tooltipAttribute("Tooltip for instance generations"),
 			"instance_sizes_tip":       tooltipAttribute("Tooltip for instance sizes"),
+			"instance_local_nvme_tip":  tooltipAttribute("Tooltip for instance local NVMe"),
 			// Additional selectors
 			"zones":             labelSelectorAttribute("Availability zones selector"),
 			"architectures":     labelSelectorAttribute("CPU architectures selector (e.g., amd64, arm64)"),
@@ -254,25 +327,39 @@ func (r *NodePolicyResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
-			"taints": schema.ListNestedAttribute{
-				Description:         "Kubernetes taints to apply to nodes",
-				MarkdownDescription: "List of Kubernetes taints to apply to nodes provisioned with this policy.",
+			"taints": taintsAttribute(
+				"Kubernetes taints to apply to nodes",
+				"List of Kubernetes taints to apply to nodes provisioned with this policy.",
+			),
+			"startup_taints": taintsAttribute(
+				"Kubernetes taints applied to nodes at startup",
+				"List of taints applied to nodes while they start up. Startup taints are expected to be removed by a daemon once the node is ready, and are not required to be tolerated by workloads.",
+			),
+			"zonal_shift": schema.SingleNestedAttribute{
+				Description:         "Availability zone outage handling",
+				MarkdownDescription: "Opt-in handling of availability zone outages, such as AWS ARC zonal shifts. Translated to provider-specific annotations on the Karpenter NodePool.",
 				Optional:            true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"key": schema.StringAttribute{
-							Description: "Taint key",
-							Required:    true,
-						},
-						"value": schema.StringAttribute{
-							Description: "Taint value",
-							Required:    true,
-						},
-						"effect": schema.StringAttribute{
-							Description:         "Taint effect (NoSchedule, PreferNoSchedule, NoExecute)",
-							MarkdownDescription: "Taint effect. Valid values: `NoSchedule`, `PreferNoSchedule`, `NoExecute`.",
-							Required:            true,
-						},
+				Attributes: map[string]schema.Attribute{
+					"respect_zonal_shift": schema.BoolAttribute{
+						Description:         "Stop provisioning nodes in impacted zones",
+						MarkdownDescription: "Stop provisioning nodes in zones impacted by a zonal shift. The other settings are ignored unless this is `true`. Default: `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"evict_impacted_nodes": schema.BoolAttribute{
+						Description:         "Terminate existing nodes in impacted zones",
+						MarkdownDescription: "Also terminate existing nodes in the impacted zone, forcing workloads to reschedule. Pod disruption budgets are respected. Default: `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"allow_zone_fallback": schema.BoolAttribute{
+						Description:         "Expand to other zones when the pinned zone is impacted",
+						MarkdownDescription: "When the policy is pinned to a single zone and that zone is impacted, expand to other zones available on the node class. Default: `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
 					},
 				},
 			},
@@ -362,9 +449,10 @@ func (r *NodePolicyResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			// Tooltips for node configuration
-			"taints_tip":      tooltipAttribute("Tooltip for taints"),
-			"disruptions_tip": tooltipAttribute("Tooltip for disruptions"),
-			"limits_tip":      tooltipAttribute("Tooltip for limits"),
+			"taints_tip":         tooltipAttribute("Tooltip for taints"),
+			"startup_taints_tip": tooltipAttribute("Tooltip for startup taints"),
+			"disruptions_tip":    tooltipAttribute("Tooltip for disruptions"),
+			"limits_tip":         tooltipAttribute("Tooltip for limits"),
 			// Karpenter naming
 			"master_override_role_name": schema.StringAttribute{
 				Description: "Master override role name for Karpenter",
@@ -618,6 +706,237 @@ func (r *NodePolicyResource) Schema(ctx context.Context, req resource.SchemaRequ
 					},
 				},
 			},
+			// GCP provider configuration
+			"gcp": schema.SingleNestedAttribute{
+				Description:         "GCP-specific node configuration",
+				MarkdownDescription: "GCP-specific configuration for nodes provisioned with this policy.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"service_account": schema.StringAttribute{
+						Description: "GCP service account email used by the nodes",
+						Optional:    true,
+					},
+					"image_selector_terms": schema.ListNestedAttribute{
+						Description: "Image selector terms",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"alias": schema.StringAttribute{
+									Description: "Image alias (e.g., ContainerOptimizedOS@latest)",
+									Optional:    true,
+								},
+								"id": schema.StringAttribute{
+									Description: "Image ID",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"image_family": schema.StringAttribute{
+						Description:         "Image family (ContainerOptimizedOS, Ubuntu)",
+						MarkdownDescription: "GCP image family. Valid values: `ContainerOptimizedOS`, `Ubuntu`.",
+						Optional:            true,
+					},
+					"kubelet_configuration": kubeletConfigurationAttribute("Kubelet configuration overrides"),
+					"labels": schema.MapAttribute{
+						Description: "GCP labels to apply to instances",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"metadata": schema.MapAttribute{
+						Description: "GCE instance metadata key-value pairs",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"network_tags": schema.ListAttribute{
+						Description: "Network tags to apply to instances, used by firewall rules",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"disks": schema.ListNestedAttribute{
+						Description: "Disks to attach to instances",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"size_gib": schema.Int32Attribute{
+									Description: "Disk size in GiB",
+									Optional:    true,
+								},
+								"category": schema.StringAttribute{
+									Description: "Disk type (e.g., pd-balanced, pd-ssd, hyperdisk-balanced)",
+									Optional:    true,
+								},
+								"boot": schema.BoolAttribute{
+									Description: "Whether this is the boot disk",
+									Optional:    true,
+									Computed:    true,
+									Default:     booldefault.StaticBool(false),
+								},
+								"secondary_boot_image": schema.StringAttribute{
+									Description: "Secondary boot disk image used to preload container images",
+									Optional:    true,
+								},
+								"secondary_boot_mode": schema.StringAttribute{
+									Description: "Secondary boot disk mode (e.g., CONTAINER_IMAGE_CACHE)",
+									Optional:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+			// OCI provider configuration
+			"oci": schema.SingleNestedAttribute{
+				Description:         "OCI-specific node configuration",
+				MarkdownDescription: "OCI-specific configuration for nodes provisioned with this policy.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"vcn_id": schema.StringAttribute{
+						Description: "OCID of the VCN the nodes are launched in",
+						Optional:    true,
+					},
+					"image_selector": schema.ListNestedAttribute{
+						Description: "Image selector terms",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									Description: "Image OCID",
+									Optional:    true,
+								},
+								"name": schema.StringAttribute{
+									Description: "Image display name",
+									Optional:    true,
+								},
+								"compartment_id": schema.StringAttribute{
+									Description: "OCID of the compartment to search for the image",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"subnet_selector": schema.ListNestedAttribute{
+						Description: "Subnet selector terms",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									Description: "Subnet OCID",
+									Optional:    true,
+								},
+								"name": schema.StringAttribute{
+									Description: "Subnet display name",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"security_group_selector": schema.ListNestedAttribute{
+						Description: "Network security group selector terms",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									Description: "Network security group OCID",
+									Optional:    true,
+								},
+								"name": schema.StringAttribute{
+									Description: "Network security group display name",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"user_data": schema.StringAttribute{
+						Description: "User data script for instance initialization",
+						Optional:    true,
+					},
+					"pre_install_script": schema.StringAttribute{
+						Description: "Script run on the node before the kubelet is installed",
+						Optional:    true,
+					},
+					"meta_data": schema.MapAttribute{
+						Description: "Instance metadata key-value pairs",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"image_family": schema.StringAttribute{
+						Description: "Image family (e.g., OracleLinux)",
+						Optional:    true,
+					},
+					"tags": schema.MapAttribute{
+						Description: "Defined tags to apply to instances",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"free_form_tags": schema.MapAttribute{
+						Description: "Free-form tags to apply to instances",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"boot_config": schema.SingleNestedAttribute{
+						Description: "Boot volume configuration",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"boot_volume_size_in_gbs": schema.Int64Attribute{
+								Description: "Boot volume size in GB",
+								Optional:    true,
+							},
+							"boot_volume_vpus_per_gb": schema.Int64Attribute{
+								Description: "Boot volume performance in VPUs per GB",
+								Optional:    true,
+							},
+						},
+					},
+					"launch_options": schema.SingleNestedAttribute{
+						Description: "Instance launch options",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"boot_volume_type": schema.StringAttribute{
+								Description: "Boot volume attachment type (e.g., PARAVIRTUALIZED, ISCSI)",
+								Optional:    true,
+							},
+							"firmware": schema.StringAttribute{
+								Description: "Firmware used to boot the instance (e.g., UEFI_64, BIOS)",
+								Optional:    true,
+							},
+							"network_type": schema.StringAttribute{
+								Description: "Emulation type for the primary VNIC (e.g., PARAVIRTUALIZED, VFIO)",
+								Optional:    true,
+							},
+							"remote_data_volume_type": schema.StringAttribute{
+								Description: "Attachment type for remote data volumes",
+								Optional:    true,
+							},
+							"is_consistent_volume_naming_enabled": schema.BoolAttribute{
+								Description: "Whether consistent volume naming is enabled",
+								Optional:    true,
+							},
+						},
+					},
+					"block_devices": schema.ListNestedAttribute{
+						Description: "Block volumes to attach to instances",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"size_in_gbs": schema.Int64Attribute{
+									Description: "Volume size in GB",
+									Optional:    true,
+								},
+								"vpus_per_gb": schema.Int64Attribute{
+									Description: "Volume performance in VPUs per GB",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"agent_list": schema.ListAttribute{
+						Description: "Oracle Cloud Agent plugins to enable on instances",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
 			// Raw Karpenter specs
 			"raw": schema.ListNestedAttribute{
 				Description:         "Raw Karpenter YAML specifications",
@@ -640,6 +959,18 @@ func (r *NodePolicyResource) Schema(ctx context.Context, req resource.SchemaRequ
 					},
 				},
 			},
+			"retain_on_destroy": schema.BoolAttribute{
+				Description:         "Keep the policy in DevZero when the resource is destroyed",
+				MarkdownDescription: "When `true`, destroying this resource only removes it from Terraform state and the policy is kept in DevZero. Default: `false` (the policy and its targets are deleted).",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"deletion_protection": deletionProtectionAttribute("node policy"),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
@@ -681,6 +1012,96 @@ func labelSelectorAttribute(description string) schema.SingleNestedAttribute {
 	}
 }
 
+// Helper function to create kubelet configuration attributes.
+func kubeletConfigurationAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"cluster_dns": schema.ListAttribute{
+				Description: "Cluster DNS server IPs",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"max_pods": schema.Int32Attribute{
+				Description: "Maximum number of pods per node",
+				Optional:    true,
+			},
+			"pods_per_core": schema.Int32Attribute{
+				Description: "Number of pods per CPU core",
+				Optional:    true,
+			},
+			"system_reserved": schema.MapAttribute{
+				Description: "Resources reserved for system daemons (e.g., cpu = \"100m\")",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"kube_reserved": schema.MapAttribute{
+				Description: "Resources reserved for Kubernetes daemons",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"eviction_hard": schema.MapAttribute{
+				Description: "Hard eviction thresholds (e.g., memory.available = \"5%\")",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"eviction_soft": schema.MapAttribute{
+				Description: "Soft eviction thresholds",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"eviction_soft_grace_period": schema.MapAttribute{
+				Description: "Grace periods for soft eviction thresholds",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"eviction_max_pod_grace_period": schema.Int32Attribute{
+				Description: "Maximum pod termination grace period in seconds for soft evictions",
+				Optional:    true,
+			},
+			"image_gc_high_threshold_percent": schema.Int32Attribute{
+				Description: "Disk usage percentage that triggers image garbage collection",
+				Optional:    true,
+			},
+			"image_gc_low_threshold_percent": schema.Int32Attribute{
+				Description: "Disk usage percentage image garbage collection frees down to",
+				Optional:    true,
+			},
+			"cpu_cfs_quota": schema.BoolAttribute{
+				Description: "Enable CPU CFS quota enforcement for containers with CPU limits",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Helper function to create taint list attributes.
+func taintsAttribute(description, markdownDescription string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description:         description,
+		MarkdownDescription: markdownDescription,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"key": schema.StringAttribute{
+					Description: "Taint key",
+					Required:    true,
+				},
+				"value": schema.StringAttribute{
+					Description: "Taint value",
+					Required:    true,
+				},
+				"effect": schema.StringAttribute{
+					Description:         "Taint effect (NoSchedule, PreferNoSchedule, NoExecute)",
+					MarkdownDescription: "Taint effect. Valid values: `NoSchedule`, `PreferNoSchedule`, `NoExecute`.",
+					Required:            true,
+				},
+			},
+		},
+	}
+}
+
 // Helper function to create tooltip attributes.
 func tooltipAttribute(description string) schema.StringAttribute {
 	return schema.StringAttribute{
@@ -689,6 +1110,18 @@ func tooltipAttribute(description string) schema.StringAttribute {
 	}
 }
 
+func (r *NodePolicyResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// A node policy provisions nodes for a single cloud provider.
+		resourcevalidator.Conflicting(
+			path.MatchRoot("aws"),
+			path.MatchRoot("azure"),
+			path.MatchRoot("gcp"),
+			path.MatchRoot("oci"),
+		),
+	}
+}
+
 func (r *NodePolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -709,6 +1142,10 @@ func (r *NodePolicyResource) Configure(ctx context.Context, req resource.Configu
 	r.client = client
 }
 
+func (r *NodePolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkDeletionProtection(ctx, req, resp, "node policy")
+}
+
 func (r *NodePolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data NodePolicyResourceModel
 
@@ -718,13 +1155,25 @@ func (r *NodePolicyResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	policy := data.toProto(ctx, &resp.Diagnostics, r.client.TeamId)
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "create", createTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
+	policy := data.toProto(ctx, &resp.Diagnostics, teamId)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	createNodePoliciesReq := &apiv1.CreateNodePoliciesRequest{
-		TeamId:   r.client.TeamId,
+		TeamId:   teamId,
 		Policies: []*apiv1.NodePolicy{policy}, // Wrap single policy in array
 	}
 
@@ -757,9 +1206,21 @@ func (r *NodePolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "read", readTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	// List all policies and find the one with matching ID
 	listNodePoliciesReq := &apiv1.ListNodePoliciesRequest{
-		TeamId: r.client.TeamId,
+		TeamId: teamId,
 	}
 
 	listNodePoliciesResp, err := r.client.RecommendationClient.ListNodePolicies(ctx, connect.NewRequest(listNodePoliciesReq))
@@ -778,7 +1239,7 @@ func (r *NodePolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	if foundPolicy == nil {
-		resp.Diagnostics.AddError("Client Error", "Node policy not found")
+		removeMissingResource(ctx, &resp.State, "Node policy", data.Id.ValueString())
 		return
 	}
 
@@ -798,9 +1259,21 @@ func (r *NodePolicyResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "update", updateTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	updateNodePolicyReq := &apiv1.UpdateNodePolicyRequest{
-		TeamId: r.client.TeamId,
-		Policy: data.toProto(ctx, &resp.Diagnostics, r.client.TeamId),
+		TeamId: teamId,
+		Policy: data.toProto(ctx, &resp.Diagnostics, teamId),
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -834,27 +1307,96 @@ func (r *NodePolicyResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	// No-op delete: just remove from state
-	// The API doesn't provide a delete endpoint, so we just remove it from Terraform state
-	// The policy will remain in the backend
-	tflog.Warn(ctx, "Node policy delete is a no-op operation. The policy will remain in the backend.")
-}
-
-func (r *NodePolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
-
-// toProto converts Terraform model to protobuf message.
-func (m *NodePolicyResourceModel) toProto(ctx context.Context, diags *diag.Diagnostics, teamId string) *apiv1.NodePolicy {
-	policy := &apiv1.NodePolicy{
-		Id:          m.Id.ValueString(),
-		Name:        m.Name.ValueString(),
-		Description: m.Description.ValueString(),
-		TeamId:      teamId,
-		Weight:      m.Weight.ValueInt32(),
+	if data.DeletionProtection.ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, "node policy", data.Id.ValueString())
+		return
 	}
 
-	// Instance selectors
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "delete", deleteTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+
+	if data.RetainOnDestroy.ValueBool() {
+		tflog.Warn(ctx, "Node policy has retain_on_destroy set. The policy will remain in the backend.", map[string]any{
+			"policy_id": data.Id.ValueString(),
+		})
+		return
+	}
+
+	deleteNodePolicyReq := &apiv1.DeleteNodePolicyRequest{
+		TeamId:   teamId,
+		PolicyId: data.Id.ValueString(),
+	}
+
+	deleteNodePolicyResp, err := r.client.RecommendationClient.DeleteNodePolicy(ctx, connect.NewRequest(deleteNodePolicyReq))
+	if err != nil {
+		// The policy is already gone, e.g. deleted from the UI, so there is nothing left to do
+		if isNotFound(err) {
+			tflog.Warn(ctx, "Node policy was already deleted from the backend.", map[string]any{
+				"policy_id": data.Id.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete node policy, got error: %s", err))
+		return
+	}
+
+	if count := deleteNodePolicyResp.Msg.DeletedTargetCount; count > 0 {
+		resp.Diagnostics.AddWarning(
+			"Node Policy Targets Deleted",
+			fmt.Sprintf("Deleting node policy %q also deleted %d node policy target(s) attached to it. "+
+				"Any devzero_node_policy_target resources referencing this policy no longer exist in DevZero.", data.Id.ValueString(), count),
+		)
+	}
+}
+
+func (r *NodePolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithName(ctx, req, resp, "node policy", r.client.teamID, r.lookupIDByName)
+
+	// Imported policies are deleted on destroy, which is how policies orphaned by
+	// the old no-op delete can be cleaned up.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("retain_on_destroy"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+}
+
+// lookupIDByName resolves the name of a node policy to its ID.
+func (r *NodePolicyResource) lookupIDByName(ctx context.Context, teamId string, name string) (string, error) {
+	listNodePoliciesReq := &apiv1.ListNodePoliciesRequest{
+		TeamId: teamId,
+	}
+
+	listNodePoliciesResp, err := r.client.RecommendationClient.ListNodePolicies(ctx, connect.NewRequest(listNodePoliciesReq))
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	for _, policy := range listNodePoliciesResp.Msg.Policies {
+		if policy.Name == name {
+			ids = append(ids, policy.Id)
+		}
+	}
+	return matchName("node policy", teamId, name, ids)
+}
+
+// toProto converts Terraform model to protobuf message.
+func (m *NodePolicyResourceModel) toProto(ctx context.Context, diags *diag.Diagnostics, teamId string) *apiv1.NodePolicy {
+	policy := &apiv1.NodePolicy{
+		Id:          m.Id.ValueString(),
+		Name:        m.Name.ValueString(),
+		Description: m.Description.ValueString(),
+		TeamId:      teamId,
+		Weight:      m.Weight.ValueInt32(),
+	}
+
+	// Instance selectors
 	if m.InstanceCategories != nil {
 		selector, err := m.InstanceCategories.toProto(ctx)
 		if err != nil {
@@ -911,6 +1453,14 @@ func (m *NodePolicyResourceModel) toProto(ctx context.Context, diags *diag.Diagn
 		}
 		policy.InstanceTypes = selector
 	}
+	if m.InstanceLocalNvme != nil {
+		selector, err := m.InstanceLocalNvme.toProto(ctx)
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert instance local NVMe: %s", err))
+			return nil
+		}
+		policy.InstanceLocalNvme = selector
+	}
 
 	// Tooltip fields (pointers for optional)
 	if !m.InstanceCategoriesTip.IsNull() {
@@ -937,6 +1487,10 @@ func (m *NodePolicyResourceModel) toProto(ctx context.Context, diags *diag.Diagn
 		val := m.InstanceSizesTip.ValueString()
 		policy.InstanceSizesTip = &val
 	}
+	if !m.InstanceLocalNvmeTip.IsNull() {
+		val := m.InstanceLocalNvmeTip.ValueString()
+		policy.InstanceLocalNvmeTip = &val
+	}
 
 	// Additional selectors
 	if m.Zones != nil {
@@ -1002,18 +1556,13 @@ func (m *NodePolicyResourceModel) toProto(ctx context.Context, diags *diag.Diagn
 
 	// Taints
 	if !m.Taints.IsNull() && !m.Taints.IsUnknown() {
-		taints, err := getElementList(ctx, m.Taints.Elements(), func(ctx context.Context, value Taint) (*apiv1.Taint, error) {
-			return &apiv1.Taint{
-				Key:    value.Key.ValueString(),
-				Value:  value.Value.ValueString(),
-				Effect: value.Effect.ValueString(),
-			}, nil
-		})
-		if err != nil {
-			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert taints: %s", err))
-			return nil
-		}
-		policy.Taints = taints
+		policy.Taints = taintsToProto(ctx, diags, "taints", m.Taints)
+	}
+	if !m.StartupTaints.IsNull() && !m.StartupTaints.IsUnknown() {
+		policy.StartupTaints = taintsToProto(ctx, diags, "startup_taints", m.StartupTaints)
+	}
+	if diags.HasError() {
+		return nil
 	}
 
 	// Disruption policy
@@ -1029,11 +1578,24 @@ func (m *NodePolicyResourceModel) toProto(ctx context.Context, diags *diag.Diagn
 		}
 	}
 
+	// Zonal shift
+	if m.ZonalShift != nil {
+		policy.ZonalShift = &apiv1.ZonalShiftConfig{
+			RespectZonalShift:  m.ZonalShift.RespectZonalShift.ValueBool(),
+			EvictImpactedNodes: m.ZonalShift.EvictImpactedNodes.ValueBool(),
+			AllowZoneFallback:  m.ZonalShift.AllowZoneFallback.ValueBool(),
+		}
+	}
+
 	// Tooltip fields for node config
 	if !m.TaintsTip.IsNull() {
 		val := m.TaintsTip.ValueString()
 		policy.TaintsTip = &val
 	}
+	if !m.StartupTaintsTip.IsNull() {
+		val := m.StartupTaintsTip.ValueString()
+		policy.StartupTaintsTip = &val
+	}
 	if !m.DisruptionsTip.IsNull() {
 		val := m.DisruptionsTip.ValueString()
 		policy.DisruptionsTip = &val
@@ -1058,6 +1620,16 @@ func (m *NodePolicyResourceModel) toProto(ctx context.Context, diags *diag.Diagn
 		policy.Azure = m.Azure.toProto(ctx, diags)
 	}
 
+	// GCP configuration
+	if m.Gcp != nil {
+		policy.Gcp = m.Gcp.toProto(ctx, diags)
+	}
+
+	// OCI configuration
+	if m.Oci != nil {
+		policy.Oci = m.Oci.toProto(ctx, diags)
+	}
+
 	// Raw Karpenter specs
 	if !m.Raw.IsNull() && !m.Raw.IsUnknown() {
 		rawSpecs, err := getElementList(ctx, m.Raw.Elements(), func(ctx context.Context, value RawKarpenterSpec) (*apiv1.RawKarpenterSpec, error) {
@@ -1105,6 +1677,9 @@ func (m *NodePolicyResourceModel) fromProto(policy *apiv1.NodePolicy) {
 	if policy.InstanceTypes != nil {
 		m.InstanceTypes = labelSelectorFromProto(policy.InstanceTypes)
 	}
+	if policy.InstanceLocalNvme != nil {
+		m.InstanceLocalNvme = labelSelectorFromProto(policy.InstanceLocalNvme)
+	}
 
 	// Tooltip fields
 	m.InstanceCategoriesTip = stringPointerValue(policy.InstanceCategoriesTip)
@@ -1113,6 +1688,7 @@ func (m *NodePolicyResourceModel) fromProto(policy *apiv1.NodePolicy) {
 	m.InstanceHypervisorsTip = stringPointerValue(policy.InstanceHypervisorsTip)
 	m.InstanceGenerationsTip = stringPointerValue(policy.InstanceGenerationsTip)
 	m.InstanceSizesTip = stringPointerValue(policy.InstanceSizesTip)
+	m.InstanceLocalNvmeTip = stringPointerValue(policy.InstanceLocalNvmeTip)
 
 	// Additional selectors
 	if policy.Zones != nil {
@@ -1142,41 +1718,8 @@ func (m *NodePolicyResourceModel) fromProto(policy *apiv1.NodePolicy) {
 	}
 
 	// Taints
-	if len(policy.Taints) > 0 {
-		taints := make([]attr.Value, 0, len(policy.Taints))
-		for _, taint := range policy.Taints {
-			taints = append(taints, types.ObjectValueMust(
-				map[string]attr.Type{
-					"key":    types.StringType,
-					"value":  types.StringType,
-					"effect": types.StringType,
-				},
-				map[string]attr.Value{
-					"key":    types.StringValue(taint.Key),
-					"value":  types.StringValue(taint.Value),
-					"effect": types.StringValue(taint.Effect),
-				},
-			))
-		}
-		m.Taints = types.ListValueMust(
-			types.ObjectType{
-				AttrTypes: map[string]attr.Type{
-					"key":    types.StringType,
-					"value":  types.StringType,
-					"effect": types.StringType,
-				},
-			},
-			taints,
-		)
-	} else {
-		m.Taints = types.ListNull(types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"key":    types.StringType,
-				"value":  types.StringType,
-				"effect": types.StringType,
-			},
-		})
-	}
+	m.Taints = taintsFromProto(policy.Taints)
+	m.StartupTaints = taintsFromProto(policy.StartupTaints)
 
 	// Disruption policy
 	if policy.Disruption != nil {
@@ -1191,8 +1734,18 @@ func (m *NodePolicyResourceModel) fromProto(policy *apiv1.NodePolicy) {
 		}
 	}
 
+	// Zonal shift
+	if zs := policy.ZonalShift; zs != nil && (zs.RespectZonalShift || zs.EvictImpactedNodes || zs.AllowZoneFallback) {
+		m.ZonalShift = &ZonalShiftConfig{
+			RespectZonalShift:  types.BoolValue(zs.RespectZonalShift),
+			EvictImpactedNodes: types.BoolValue(zs.EvictImpactedNodes),
+			AllowZoneFallback:  types.BoolValue(zs.AllowZoneFallback),
+		}
+	}
+
 	// Tooltip fields for node config
 	m.TaintsTip = stringPointerValue(policy.TaintsTip)
+	m.StartupTaintsTip = stringPointerValue(policy.StartupTaintsTip)
 	m.DisruptionsTip = stringPointerValue(policy.DisruptionsTip)
 	m.LimitsTip = stringPointerValue(policy.LimitsTip)
 
@@ -1211,6 +1764,16 @@ func (m *NodePolicyResourceModel) fromProto(policy *apiv1.NodePolicy) {
 		m.Azure = azureNodeClassFromProto(policy.Azure)
 	}
 
+	// GCP configuration
+	if policy.Gcp != nil && !isGCPSpecEmpty(policy.Gcp) {
+		m.Gcp = gcpNodeClassFromProto(policy.Gcp)
+	}
+
+	// OCI configuration
+	if policy.Oci != nil && !isOCISpecEmpty(policy.Oci) {
+		m.Oci = ociNodeClassFromProto(policy.Oci)
+	}
+
 	// Raw specs
 	if len(policy.Raw) > 0 {
 		rawSpecs := make([]attr.Value, 0, len(policy.Raw))
@@ -1260,6 +1823,13 @@ func int32PointerValue(val *int32) types.Int32 {
 	return types.Int32Value(*val)
 }
 
+func int64ZeroNull(val int64) types.Int64 {
+	if val == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(val)
+}
+
 func boolPointerValue(val *bool) types.Bool {
 	if val == nil {
 		return types.BoolNull()
@@ -1700,80 +2270,7 @@ func (aws *AWSNodeClass) toProto(ctx context.Context, diags *diag.Diagnostics) *
 
 	// Kubelet configuration
 	if aws.Kubelet != nil {
-		kubelet := &apiv1.KubeletConfiguration{}
-		if !aws.Kubelet.ClusterDns.IsNull() {
-			dns, err := getStringList(ctx, aws.Kubelet.ClusterDns.Elements())
-			if err != nil {
-				diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert cluster_dns: %s", err))
-				return nil
-			}
-			kubelet.ClusterDns = dns
-		}
-		if !aws.Kubelet.MaxPods.IsNull() {
-			val := aws.Kubelet.MaxPods.ValueInt32()
-			kubelet.MaxPods = &val
-		}
-		if !aws.Kubelet.PodsPerCore.IsNull() {
-			val := aws.Kubelet.PodsPerCore.ValueInt32()
-			kubelet.PodsPerCore = &val
-		}
-		if !aws.Kubelet.SystemReserved.IsNull() {
-			m, err := getStringMap(ctx, aws.Kubelet.SystemReserved.Elements())
-			if err != nil {
-				diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert system_reserved: %s", err))
-				return nil
-			}
-			kubelet.SystemReserved = m
-		}
-		if !aws.Kubelet.KubeReserved.IsNull() {
-			m, err := getStringMap(ctx, aws.Kubelet.KubeReserved.Elements())
-			if err != nil {
-				diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert kube_reserved: %s", err))
-				return nil
-			}
-			kubelet.KubeReserved = m
-		}
-		if !aws.Kubelet.EvictionHard.IsNull() {
-			m, err := getStringMap(ctx, aws.Kubelet.EvictionHard.Elements())
-			if err != nil {
-				diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert eviction_hard: %s", err))
-				return nil
-			}
-			kubelet.EvictionHard = m
-		}
-		if !aws.Kubelet.EvictionSoft.IsNull() {
-			m, err := getStringMap(ctx, aws.Kubelet.EvictionSoft.Elements())
-			if err != nil {
-				diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert eviction_soft: %s", err))
-				return nil
-			}
-			kubelet.EvictionSoft = m
-		}
-		if !aws.Kubelet.EvictionSoftGracePeriod.IsNull() {
-			m, err := getStringMap(ctx, aws.Kubelet.EvictionSoftGracePeriod.Elements())
-			if err != nil {
-				diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert eviction_soft_grace_period: %s", err))
-				return nil
-			}
-			kubelet.EvictionSoftGracePeriod = m
-		}
-		if !aws.Kubelet.EvictionMaxPodGracePeriod.IsNull() {
-			val := aws.Kubelet.EvictionMaxPodGracePeriod.ValueInt32()
-			kubelet.EvictionMaxPodGracePeriod = &val
-		}
-		if !aws.Kubelet.ImageGcHighThresholdPercent.IsNull() {
-			val := aws.Kubelet.ImageGcHighThresholdPercent.ValueInt32()
-			kubelet.ImageGcHighThresholdPercent = &val
-		}
-		if !aws.Kubelet.ImageGcLowThresholdPercent.IsNull() {
-			val := aws.Kubelet.ImageGcLowThresholdPercent.ValueInt32()
-			kubelet.ImageGcLowThresholdPercent = &val
-		}
-		if !aws.Kubelet.CpuCfsQuota.IsNull() {
-			val := aws.Kubelet.CpuCfsQuota.ValueBool()
-			kubelet.CpuCfsQuota = &val
-		}
-		spec.Kubelet = kubelet
+		spec.Kubelet = aws.Kubelet.toProto(ctx, diags)
 	}
 
 	// Context
@@ -2139,25 +2636,7 @@ func awsNodeClassFromProto(spec *apiv1.AWSNodeClassSpec) *AWSNodeClass {
 
 	// Kubelet configuration
 	if spec.Kubelet != nil {
-		k := spec.Kubelet
-		kubelet := &KubeletConfiguration{}
-		if len(k.ClusterDns) > 0 {
-			kubelet.ClusterDns = types.ListValueMust(types.StringType, fromStringList(k.ClusterDns))
-		} else {
-			kubelet.ClusterDns = types.ListNull(types.StringType)
-		}
-		kubelet.MaxPods = int32PointerValue(k.MaxPods)
-		kubelet.PodsPerCore = int32PointerValue(k.PodsPerCore)
-		kubelet.SystemReserved = stringMapOrNull(k.SystemReserved)
-		kubelet.KubeReserved = stringMapOrNull(k.KubeReserved)
-		kubelet.EvictionHard = stringMapOrNull(k.EvictionHard)
-		kubelet.EvictionSoft = stringMapOrNull(k.EvictionSoft)
-		kubelet.EvictionSoftGracePeriod = stringMapOrNull(k.EvictionSoftGracePeriod)
-		kubelet.EvictionMaxPodGracePeriod = int32PointerValue(k.EvictionMaxPodGracePeriod)
-		kubelet.ImageGcHighThresholdPercent = int32PointerValue(k.ImageGcHighThresholdPercent)
-		kubelet.ImageGcLowThresholdPercent = int32PointerValue(k.ImageGcLowThresholdPercent)
-		kubelet.CpuCfsQuota = boolPointerValue(k.CpuCfsQuota)
-		aws.Kubelet = kubelet
+		aws.Kubelet = kubeletConfigurationFromProto(spec.Kubelet)
 	}
 
 	// Context
@@ -2343,6 +2822,647 @@ func azureNodeClassFromProto(spec *apiv1.AzureNodeClassSpec) *AzureNodeClass {
 	return azure
 }
 
+// toProto for KubeletConfiguration.
+func (k *KubeletConfiguration) toProto(ctx context.Context, diags *diag.Diagnostics) *apiv1.KubeletConfiguration {
+	kubelet := &apiv1.KubeletConfiguration{}
+	if !k.ClusterDns.IsNull() {
+		dns, err := getStringList(ctx, k.ClusterDns.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert cluster_dns: %s", err))
+			return nil
+		}
+		kubelet.ClusterDns = dns
+	}
+	if !k.MaxPods.IsNull() {
+		val := k.MaxPods.ValueInt32()
+		kubelet.MaxPods = &val
+	}
+	if !k.PodsPerCore.IsNull() {
+		val := k.PodsPerCore.ValueInt32()
+		kubelet.PodsPerCore = &val
+	}
+	if !k.SystemReserved.IsNull() {
+		m, err := getStringMap(ctx, k.SystemReserved.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert system_reserved: %s", err))
+			return nil
+		}
+		kubelet.SystemReserved = m
+	}
+	if !k.KubeReserved.IsNull() {
+		m, err := getStringMap(ctx, k.KubeReserved.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert kube_reserved: %s", err))
+			return nil
+		}
+		kubelet.KubeReserved = m
+	}
+	if !k.EvictionHard.IsNull() {
+		m, err := getStringMap(ctx, k.EvictionHard.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert eviction_hard: %s", err))
+			return nil
+		}
+		kubelet.EvictionHard = m
+	}
+	if !k.EvictionSoft.IsNull() {
+		m, err := getStringMap(ctx, k.EvictionSoft.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert eviction_soft: %s", err))
+			return nil
+		}
+		kubelet.EvictionSoft = m
+	}
+	if !k.EvictionSoftGracePeriod.IsNull() {
+		m, err := getStringMap(ctx, k.EvictionSoftGracePeriod.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert eviction_soft_grace_period: %s", err))
+			return nil
+		}
+		kubelet.EvictionSoftGracePeriod = m
+	}
+	if !k.EvictionMaxPodGracePeriod.IsNull() {
+		val := k.EvictionMaxPodGracePeriod.ValueInt32()
+		kubelet.EvictionMaxPodGracePeriod = &val
+	}
+	if !k.ImageGcHighThresholdPercent.IsNull() {
+		val := k.ImageGcHighThresholdPercent.ValueInt32()
+		kubelet.ImageGcHighThresholdPercent = &val
+	}
+	if !k.ImageGcLowThresholdPercent.IsNull() {
+		val := k.ImageGcLowThresholdPercent.ValueInt32()
+		kubelet.ImageGcLowThresholdPercent = &val
+	}
+	if !k.CpuCfsQuota.IsNull() {
+		val := k.CpuCfsQuota.ValueBool()
+		kubelet.CpuCfsQuota = &val
+	}
+	return kubelet
+}
+
+// Helper function to convert a list of Taint objects to proto.
+func taintsToProto(ctx context.Context, diags *diag.Diagnostics, name string, list types.List) []*apiv1.Taint {
+	taints, err := getElementList(ctx, list.Elements(), func(ctx context.Context, value Taint) (*apiv1.Taint, error) {
+		return &apiv1.Taint{
+			Key:    value.Key.ValueString(),
+			Value:  value.Value.ValueString(),
+			Effect: value.Effect.ValueString(),
+		}, nil
+	})
+	if err != nil {
+		diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert %s: %s", name, err))
+		return nil
+	}
+	return taints
+}
+
+// Helper function to convert proto taints to a list of Taint objects.
+func taintsFromProto(taints []*apiv1.Taint) types.List {
+	taintType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"key":    types.StringType,
+			"value":  types.StringType,
+			"effect": types.StringType,
+		},
+	}
+	if len(taints) == 0 {
+		return types.ListNull(taintType)
+	}
+	values := make([]attr.Value, 0, len(taints))
+	for _, taint := range taints {
+		values = append(values, types.ObjectValueMust(
+			taintType.AttrTypes,
+			map[string]attr.Value{
+				"key":    types.StringValue(taint.Key),
+				"value":  types.StringValue(taint.Value),
+				"effect": types.StringValue(taint.Effect),
+			},
+		))
+	}
+	return types.ListValueMust(taintType, values)
+}
+
+// Helper function for kubelet configuration from proto.
+func kubeletConfigurationFromProto(k *apiv1.KubeletConfiguration) *KubeletConfiguration {
+	kubelet := &KubeletConfiguration{}
+	if len(k.ClusterDns) > 0 {
+		kubelet.ClusterDns = types.ListValueMust(types.StringType, fromStringList(k.ClusterDns))
+	} else {
+		kubelet.ClusterDns = types.ListNull(types.StringType)
+	}
+	kubelet.MaxPods = int32PointerValue(k.MaxPods)
+	kubelet.PodsPerCore = int32PointerValue(k.PodsPerCore)
+	kubelet.SystemReserved = stringMapOrNull(k.SystemReserved)
+	kubelet.KubeReserved = stringMapOrNull(k.KubeReserved)
+	kubelet.EvictionHard = stringMapOrNull(k.EvictionHard)
+	kubelet.EvictionSoft = stringMapOrNull(k.EvictionSoft)
+	kubelet.EvictionSoftGracePeriod = stringMapOrNull(k.EvictionSoftGracePeriod)
+	kubelet.EvictionMaxPodGracePeriod = int32PointerValue(k.EvictionMaxPodGracePeriod)
+	kubelet.ImageGcHighThresholdPercent = int32PointerValue(k.ImageGcHighThresholdPercent)
+	kubelet.ImageGcLowThresholdPercent = int32PointerValue(k.ImageGcLowThresholdPercent)
+	kubelet.CpuCfsQuota = boolPointerValue(k.CpuCfsQuota)
+	return kubelet
+}
+
+// GCP Node Class conversion functions.
+func (gcp *GCPNodeClass) toProto(ctx context.Context, diags *diag.Diagnostics) *apiv1.GCPNodeClassSpec {
+	spec := &apiv1.GCPNodeClassSpec{
+		ServiceAccount: gcp.ServiceAccount.ValueString(),
+	}
+
+	// Image selector terms
+	if !gcp.ImageSelectorTerms.IsNull() && !gcp.ImageSelectorTerms.IsUnknown() {
+		var terms []*apiv1.GCPImageSelectorTerm
+		for _, elem := range gcp.ImageSelectorTerms.Elements() {
+			objVal, ok := elem.(types.Object)
+			if !ok {
+				continue
+			}
+			attrs := objVal.Attributes()
+			term := &apiv1.GCPImageSelectorTerm{}
+			if alias, ok := attrs["alias"].(types.String); ok && !alias.IsNull() {
+				term.Alias = alias.ValueString()
+			}
+			if id, ok := attrs["id"].(types.String); ok && !id.IsNull() {
+				term.Id = id.ValueString()
+			}
+			terms = append(terms, term)
+		}
+		spec.ImageSelectorTerms = terms
+	}
+
+	if !gcp.ImageFamily.IsNull() {
+		val := gcp.ImageFamily.ValueString()
+		spec.ImageFamily = &val
+	}
+
+	if gcp.KubeletConfiguration != nil {
+		spec.KubeletConfiguration = gcp.KubeletConfiguration.toProto(ctx, diags)
+	}
+
+	if !gcp.Labels.IsNull() {
+		labels, err := getStringMap(ctx, gcp.Labels.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert GCP labels: %s", err))
+			return nil
+		}
+		spec.Labels = labels
+	}
+
+	if !gcp.Metadata.IsNull() {
+		metadata, err := getStringMap(ctx, gcp.Metadata.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert GCP metadata: %s", err))
+			return nil
+		}
+		spec.Metadata = metadata
+	}
+
+	if !gcp.NetworkTags.IsNull() {
+		tags, err := getStringList(ctx, gcp.NetworkTags.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert GCP network tags: %s", err))
+			return nil
+		}
+		spec.NetworkTags = tags
+	}
+
+	// Disks
+	if !gcp.Disks.IsNull() && !gcp.Disks.IsUnknown() {
+		var disks []*apiv1.GCPDisk
+		for _, elem := range gcp.Disks.Elements() {
+			objVal, ok := elem.(types.Object)
+			if !ok {
+				continue
+			}
+			attrs := objVal.Attributes()
+			disk := &apiv1.GCPDisk{}
+			if sizeGib, ok := attrs["size_gib"].(types.Int32); ok && !sizeGib.IsNull() {
+				disk.SizeGib = sizeGib.ValueInt32()
+			}
+			if category, ok := attrs["category"].(types.String); ok && !category.IsNull() {
+				disk.Category = category.ValueString()
+			}
+			if boot, ok := attrs["boot"].(types.Bool); ok && !boot.IsNull() {
+				disk.Boot = boot.ValueBool()
+			}
+			if image, ok := attrs["secondary_boot_image"].(types.String); ok && !image.IsNull() {
+				disk.SecondaryBootImage = image.ValueString()
+			}
+			if mode, ok := attrs["secondary_boot_mode"].(types.String); ok && !mode.IsNull() {
+				disk.SecondaryBootMode = mode.ValueString()
+			}
+			disks = append(disks, disk)
+		}
+		spec.Disks = disks
+	}
+
+	return spec
+}
+
+// Helper to check if GCP spec is empty (all fields are nil/empty).
+func isGCPSpecEmpty(spec *apiv1.GCPNodeClassSpec) bool {
+	if spec == nil {
+		return true
+	}
+	return spec.ServiceAccount == "" &&
+		len(spec.ImageSelectorTerms) == 0 &&
+		spec.ImageFamily == nil &&
+		spec.KubeletConfiguration == nil &&
+		len(spec.Labels) == 0 &&
+		len(spec.Metadata) == 0 &&
+		len(spec.NetworkTags) == 0 &&
+		len(spec.Disks) == 0
+}
+
+func gcpNodeClassFromProto(spec *apiv1.GCPNodeClassSpec) *GCPNodeClass {
+	gcp := &GCPNodeClass{}
+
+	gcp.ServiceAccount = stringValue(spec.ServiceAccount)
+
+	// Image selector terms
+	imageSelectorTermType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"alias": types.StringType,
+		"id":    types.StringType,
+	}}
+	if len(spec.ImageSelectorTerms) > 0 {
+		terms := make([]attr.Value, 0, len(spec.ImageSelectorTerms))
+		for _, term := range spec.ImageSelectorTerms {
+			terms = append(terms, types.ObjectValueMust(
+				imageSelectorTermType.AttrTypes,
+				map[string]attr.Value{
+					"alias": stringValue(term.Alias),
+					"id":    stringValue(term.Id),
+				},
+			))
+		}
+		gcp.ImageSelectorTerms = types.ListValueMust(imageSelectorTermType, terms)
+	} else {
+		gcp.ImageSelectorTerms = types.ListNull(imageSelectorTermType)
+	}
+
+	gcp.ImageFamily = stringPointerValue(spec.ImageFamily)
+
+	if spec.KubeletConfiguration != nil {
+		gcp.KubeletConfiguration = kubeletConfigurationFromProto(spec.KubeletConfiguration)
+	}
+
+	gcp.Labels = stringMapOrNull(spec.Labels)
+	gcp.Metadata = stringMapOrNull(spec.Metadata)
+
+	if len(spec.NetworkTags) > 0 {
+		gcp.NetworkTags = types.ListValueMust(types.StringType, fromStringList(spec.NetworkTags))
+	} else {
+		gcp.NetworkTags = types.ListNull(types.StringType)
+	}
+
+	// Disks
+	diskType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"size_gib":             types.Int32Type,
+		"category":             types.StringType,
+		"boot":                 types.BoolType,
+		"secondary_boot_image": types.StringType,
+		"secondary_boot_mode":  types.StringType,
+	}}
+	if len(spec.Disks) > 0 {
+		disks := make([]attr.Value, 0, len(spec.Disks))
+		for _, disk := range spec.Disks {
+			sizeGib := types.Int32Null()
+			if disk.SizeGib != 0 {
+				sizeGib = types.Int32Value(disk.SizeGib)
+			}
+			disks = append(disks, types.ObjectValueMust(
+				diskType.AttrTypes,
+				map[string]attr.Value{
+					"size_gib":             sizeGib,
+					"category":             stringValue(disk.Category),
+					"boot":                 types.BoolValue(disk.Boot),
+					"secondary_boot_image": stringValue(disk.SecondaryBootImage),
+					"secondary_boot_mode":  stringValue(disk.SecondaryBootMode),
+				},
+			))
+		}
+		gcp.Disks = types.ListValueMust(diskType, disks)
+	} else {
+		gcp.Disks = types.ListNull(diskType)
+	}
+
+	return gcp
+}
+
+// OCI Node Class conversion functions.
+func (oci *OCINodeClass) toProto(ctx context.Context, diags *diag.Diagnostics) *apiv1.OCINodeClassSpec {
+	spec := &apiv1.OCINodeClassSpec{
+		VcnId:       oci.VcnId.ValueString(),
+		ImageFamily: oci.ImageFamily.ValueString(),
+	}
+
+	// Image selector terms
+	if !oci.ImageSelector.IsNull() && !oci.ImageSelector.IsUnknown() {
+		var terms []*apiv1.OCIImageSelectorTerm
+		for _, elem := range oci.ImageSelector.Elements() {
+			objVal, ok := elem.(types.Object)
+			if !ok {
+				continue
+			}
+			attrs := objVal.Attributes()
+			term := &apiv1.OCIImageSelectorTerm{}
+			if id, ok := attrs["id"].(types.String); ok && !id.IsNull() {
+				term.Id = id.ValueString()
+			}
+			if name, ok := attrs["name"].(types.String); ok && !name.IsNull() {
+				term.Name = name.ValueString()
+			}
+			if compartmentId, ok := attrs["compartment_id"].(types.String); ok && !compartmentId.IsNull() {
+				term.CompartmentId = compartmentId.ValueString()
+			}
+			terms = append(terms, term)
+		}
+		spec.ImageSelector = terms
+	}
+
+	// Subnet selector terms
+	if !oci.SubnetSelector.IsNull() && !oci.SubnetSelector.IsUnknown() {
+		var terms []*apiv1.OCISubnetSelectorTerm
+		for _, elem := range oci.SubnetSelector.Elements() {
+			objVal, ok := elem.(types.Object)
+			if !ok {
+				continue
+			}
+			attrs := objVal.Attributes()
+			term := &apiv1.OCISubnetSelectorTerm{}
+			if id, ok := attrs["id"].(types.String); ok && !id.IsNull() {
+				term.Id = id.ValueString()
+			}
+			if name, ok := attrs["name"].(types.String); ok && !name.IsNull() {
+				term.Name = name.ValueString()
+			}
+			terms = append(terms, term)
+		}
+		spec.SubnetSelector = terms
+	}
+
+	// Security group selector terms
+	if !oci.SecurityGroupSelector.IsNull() && !oci.SecurityGroupSelector.IsUnknown() {
+		var terms []*apiv1.OCISecurityGroupSelectorTerm
+		for _, elem := range oci.SecurityGroupSelector.Elements() {
+			objVal, ok := elem.(types.Object)
+			if !ok {
+				continue
+			}
+			attrs := objVal.Attributes()
+			term := &apiv1.OCISecurityGroupSelectorTerm{}
+			if id, ok := attrs["id"].(types.String); ok && !id.IsNull() {
+				term.Id = id.ValueString()
+			}
+			if name, ok := attrs["name"].(types.String); ok && !name.IsNull() {
+				term.Name = name.ValueString()
+			}
+			terms = append(terms, term)
+		}
+		spec.SecurityGroupSelector = terms
+	}
+
+	if !oci.UserData.IsNull() {
+		val := oci.UserData.ValueString()
+		spec.UserData = &val
+	}
+	if !oci.PreInstallScript.IsNull() {
+		val := oci.PreInstallScript.ValueString()
+		spec.PreInstallScript = &val
+	}
+
+	if !oci.MetaData.IsNull() {
+		metaData, err := getStringMap(ctx, oci.MetaData.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert OCI meta_data: %s", err))
+			return nil
+		}
+		spec.MetaData = metaData
+	}
+
+	if !oci.Tags.IsNull() {
+		tags, err := getStringMap(ctx, oci.Tags.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert OCI tags: %s", err))
+			return nil
+		}
+		spec.Tags = tags
+	}
+
+	if !oci.FreeFormTags.IsNull() {
+		tags, err := getStringMap(ctx, oci.FreeFormTags.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert OCI free_form_tags: %s", err))
+			return nil
+		}
+		spec.FreeFormTags = tags
+	}
+
+	if oci.BootConfig != nil {
+		spec.BootConfig = &apiv1.OCIBootConfig{
+			BootVolumeSizeInGbs: oci.BootConfig.BootVolumeSizeInGbs.ValueInt64(),
+			BootVolumeVpusPerGb: oci.BootConfig.BootVolumeVpusPerGb.ValueInt64(),
+		}
+	}
+
+	if oci.LaunchOptions != nil {
+		opts := &apiv1.OCILaunchOptions{}
+		if !oci.LaunchOptions.BootVolumeType.IsNull() {
+			val := oci.LaunchOptions.BootVolumeType.ValueString()
+			opts.BootVolumeType = &val
+		}
+		if !oci.LaunchOptions.Firmware.IsNull() {
+			val := oci.LaunchOptions.Firmware.ValueString()
+			opts.Firmware = &val
+		}
+		if !oci.LaunchOptions.NetworkType.IsNull() {
+			val := oci.LaunchOptions.NetworkType.ValueString()
+			opts.NetworkType = &val
+		}
+		if !oci.LaunchOptions.RemoteDataVolumeType.IsNull() {
+			val := oci.LaunchOptions.RemoteDataVolumeType.ValueString()
+			opts.RemoteDataVolumeType = &val
+		}
+		if !oci.LaunchOptions.IsConsistentVolumeNamingEnabled.IsNull() {
+			val := oci.LaunchOptions.IsConsistentVolumeNamingEnabled.ValueBool()
+			opts.IsConsistentVolumeNamingEnabled = &val
+		}
+		spec.LaunchOptions = opts
+	}
+
+	// Block devices
+	if !oci.BlockDevices.IsNull() && !oci.BlockDevices.IsUnknown() {
+		var devices []*apiv1.OCIVolumeAttributes
+		for _, elem := range oci.BlockDevices.Elements() {
+			objVal, ok := elem.(types.Object)
+			if !ok {
+				continue
+			}
+			attrs := objVal.Attributes()
+			device := &apiv1.OCIVolumeAttributes{}
+			if size, ok := attrs["size_in_gbs"].(types.Int64); ok && !size.IsNull() {
+				device.SizeInGbs = size.ValueInt64()
+			}
+			if vpus, ok := attrs["vpus_per_gb"].(types.Int64); ok && !vpus.IsNull() {
+				device.VpusPerGb = vpus.ValueInt64()
+			}
+			devices = append(devices, device)
+		}
+		spec.BlockDevices = devices
+	}
+
+	if !oci.AgentList.IsNull() {
+		agents, err := getStringList(ctx, oci.AgentList.Elements())
+		if err != nil {
+			diags.AddError("Conversion Error", fmt.Sprintf("Unable to convert OCI agent_list: %s", err))
+			return nil
+		}
+		spec.AgentList = agents
+	}
+
+	return spec
+}
+
+// Helper to check if OCI spec is empty (all fields are nil/empty).
+func isOCISpecEmpty(spec *apiv1.OCINodeClassSpec) bool {
+	if spec == nil {
+		return true
+	}
+	return spec.VcnId == "" &&
+		len(spec.ImageSelector) == 0 &&
+		len(spec.SubnetSelector) == 0 &&
+		len(spec.SecurityGroupSelector) == 0 &&
+		spec.UserData == nil &&
+		spec.PreInstallScript == nil &&
+		len(spec.MetaData) == 0 &&
+		spec.ImageFamily == "" &&
+		len(spec.Tags) == 0 &&
+		len(spec.FreeFormTags) == 0 &&
+		spec.BootConfig == nil &&
+		spec.LaunchOptions == nil &&
+		len(spec.BlockDevices) == 0 &&
+		len(spec.AgentList) == 0
+}
+
+func ociNodeClassFromProto(spec *apiv1.OCINodeClassSpec) *OCINodeClass {
+	oci := &OCINodeClass{}
+
+	oci.VcnId = stringValue(spec.VcnId)
+	oci.ImageFamily = stringValue(spec.ImageFamily)
+	oci.UserData = stringPointerValue(spec.UserData)
+	oci.PreInstallScript = stringPointerValue(spec.PreInstallScript)
+
+	// Image selector terms
+	imageSelectorType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"id":             types.StringType,
+		"name":           types.StringType,
+		"compartment_id": types.StringType,
+	}}
+	if len(spec.ImageSelector) > 0 {
+		terms := make([]attr.Value, 0, len(spec.ImageSelector))
+		for _, term := range spec.ImageSelector {
+			terms = append(terms, types.ObjectValueMust(
+				imageSelectorType.AttrTypes,
+				map[string]attr.Value{
+					"id":             stringValue(term.Id),
+					"name":           stringValue(term.Name),
+					"compartment_id": stringValue(term.CompartmentId),
+				},
+			))
+		}
+		oci.ImageSelector = types.ListValueMust(imageSelectorType, terms)
+	} else {
+		oci.ImageSelector = types.ListNull(imageSelectorType)
+	}
+
+	// Subnet and security group selector terms share the same shape
+	selectorType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"id":   types.StringType,
+		"name": types.StringType,
+	}}
+	if len(spec.SubnetSelector) > 0 {
+		terms := make([]attr.Value, 0, len(spec.SubnetSelector))
+		for _, term := range spec.SubnetSelector {
+			terms = append(terms, types.ObjectValueMust(
+				selectorType.AttrTypes,
+				map[string]attr.Value{
+					"id":   stringValue(term.Id),
+					"name": stringValue(term.Name),
+				},
+			))
+		}
+		oci.SubnetSelector = types.ListValueMust(selectorType, terms)
+	} else {
+		oci.SubnetSelector = types.ListNull(selectorType)
+	}
+	if len(spec.SecurityGroupSelector) > 0 {
+		terms := make([]attr.Value, 0, len(spec.SecurityGroupSelector))
+		for _, term := range spec.SecurityGroupSelector {
+			terms = append(terms, types.ObjectValueMust(
+				selectorType.AttrTypes,
+				map[string]attr.Value{
+					"id":   stringValue(term.Id),
+					"name": stringValue(term.Name),
+				},
+			))
+		}
+		oci.SecurityGroupSelector = types.ListValueMust(selectorType, terms)
+	} else {
+		oci.SecurityGroupSelector = types.ListNull(selectorType)
+	}
+
+	oci.MetaData = stringMapOrNull(spec.MetaData)
+	oci.Tags = stringMapOrNull(spec.Tags)
+	oci.FreeFormTags = stringMapOrNull(spec.FreeFormTags)
+
+	if spec.BootConfig != nil {
+		oci.BootConfig = &OCIBootConfig{
+			BootVolumeSizeInGbs: int64ZeroNull(spec.BootConfig.BootVolumeSizeInGbs),
+			BootVolumeVpusPerGb: int64ZeroNull(spec.BootConfig.BootVolumeVpusPerGb),
+		}
+	}
+
+	if spec.LaunchOptions != nil {
+		oci.LaunchOptions = &OCILaunchOptions{
+			BootVolumeType:                  stringPointerValue(spec.LaunchOptions.BootVolumeType),
+			Firmware:                        stringPointerValue(spec.LaunchOptions.Firmware),
+			NetworkType:                     stringPointerValue(spec.LaunchOptions.NetworkType),
+			RemoteDataVolumeType:            stringPointerValue(spec.LaunchOptions.RemoteDataVolumeType),
+			IsConsistentVolumeNamingEnabled: boolPointerValue(spec.LaunchOptions.IsConsistentVolumeNamingEnabled),
+		}
+	}
+
+	// Block devices
+	blockDeviceType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"size_in_gbs": types.Int64Type,
+		"vpus_per_gb": types.Int64Type,
+	}}
+	if len(spec.BlockDevices) > 0 {
+		devices := make([]attr.Value, 0, len(spec.BlockDevices))
+		for _, device := range spec.BlockDevices {
+			devices = append(devices, types.ObjectValueMust(
+				blockDeviceType.AttrTypes,
+				map[string]attr.Value{
+					"size_in_gbs": int64ZeroNull(device.SizeInGbs),
+					"vpus_per_gb": int64ZeroNull(device.VpusPerGb),
+				},
+			))
+		}
+		oci.BlockDevices = types.ListValueMust(blockDeviceType, devices)
+	} else {
+		oci.BlockDevices = types.ListNull(blockDeviceType)
+	}
+
+	if len(spec.AgentList) > 0 {
+		oci.AgentList = types.ListValueMust(types.StringType, fromStringList(spec.AgentList))
+	} else {
+		oci.AgentList = types.ListNull(types.StringType)
+	}
+
+	return oci
+}
+
 // Helper functions for enum conversions.
 //
 //nolint:unparam // Only RAID0 is currently supported, but function provides extensibility
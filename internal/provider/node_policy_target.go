@@ -5,8 +5,8 @@ import (
 	"fmt"
 
 	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -36,11 +36,14 @@ type NodePolicyTargetResource struct {
 // NodePolicyTargetResourceModel describes the resource data model.
 type NodePolicyTargetResourceModel struct {
 	Id          types.String `tfsdk:"id"`
+	TeamId      types.String `tfsdk:"team_id"`
 	PolicyId    types.String `tfsdk:"policy_id"`
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
 	Enabled     types.Bool   `tfsdk:"enabled"`
 	ClusterIds  types.List   `tfsdk:"cluster_ids"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *NodePolicyTargetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,6 +63,7 @@ func (r *NodePolicyTargetResource) Schema(ctx context.Context, req resource.Sche
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"team_id": teamIDAttribute(),
 			"policy_id": schema.StringAttribute{
 				Description:         "Node policy to attach this target to",
 				MarkdownDescription: "Node policy to attach this target to. Must reference an existing `devzero_node_policy` resource ID.",
@@ -91,6 +95,10 @@ func (r *NodePolicyTargetResource) Schema(ctx context.Context, req resource.Sche
 				ElementType:         types.StringType,
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -123,7 +131,19 @@ func (r *NodePolicyTargetResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	target := data.toProto(ctx, &resp.Diagnostics, r.client.TeamId)
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "create", createTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
+	target := data.toProto(ctx, &resp.Diagnostics, teamId)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -161,9 +181,21 @@ func (r *NodePolicyTargetResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "read", readTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	// List all targets and find the one with matching ID
 	listNodePolicyTargetsReq := &apiv1.ListNodePolicyTargetsRequest{
-		TeamId: r.client.TeamId,
+		TeamId: teamId,
 	}
 
 	listNodePolicyTargetsResp, err := r.client.RecommendationClient.ListNodePolicyTargets(ctx, connect.NewRequest(listNodePolicyTargetsReq))
@@ -182,7 +214,7 @@ func (r *NodePolicyTargetResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	if foundTarget == nil {
-		resp.Diagnostics.AddError("Client Error", "Node policy target not found")
+		removeMissingResource(ctx, &resp.State, "Node policy target", data.Id.ValueString())
 		return
 	}
 
@@ -202,8 +234,20 @@ func (r *NodePolicyTargetResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "update", updateTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	updateNodePolicyTargetReq := &apiv1.UpdateNodePolicyTargetRequest{
-		Target: data.toProto(ctx, &resp.Diagnostics, r.client.TeamId),
+		Target: data.toProto(ctx, &resp.Diagnostics, teamId),
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -244,7 +288,7 @@ func (r *NodePolicyTargetResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *NodePolicyTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithTeamID(ctx, req, resp)
 }
 
 // toProto converts Terraform model to protobuf message.
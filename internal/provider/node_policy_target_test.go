@@ -2,14 +2,19 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
+	"connectrpc.com/connect"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
 	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
 )
 
@@ -333,3 +338,108 @@ func validateNodePolicyTargetSchema(t *testing.T, schema schema.Schema) {
 		}
 	}
 }
+
+func TestNodePolicyTargetResourceRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		listNodePolicyTargets func(context.Context, *connect.Request[apiv1.ListNodePolicyTargetsRequest]) (*connect.Response[apiv1.ListNodePolicyTargetsResponse], error)
+		removed               bool
+	}{
+		"not in list": {
+			listNodePolicyTargets: func(context.Context, *connect.Request[apiv1.ListNodePolicyTargetsRequest]) (*connect.Response[apiv1.ListNodePolicyTargetsResponse], error) {
+				return connect.NewResponse(&apiv1.ListNodePolicyTargetsResponse{Targets: []*apiv1.NodePolicyTarget{{TargetId: "target-2"}}}), nil
+			},
+			removed: true,
+		},
+		"empty list": {
+			listNodePolicyTargets: func(context.Context, *connect.Request[apiv1.ListNodePolicyTargetsRequest]) (*connect.Response[apiv1.ListNodePolicyTargetsResponse], error) {
+				return connect.NewResponse(&apiv1.ListNodePolicyTargetsResponse{}), nil
+			},
+			removed: true,
+		},
+		"unavailable": {
+			listNodePolicyTargets: func(context.Context, *connect.Request[apiv1.ListNodePolicyTargetsRequest]) (*connect.Response[apiv1.ListNodePolicyTargetsResponse], error) {
+				return nil, connect.NewError(connect.CodeUnavailable, errors.New("service unavailable"))
+			},
+			removed: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &NodePolicyTargetResource{client: &ClientSet{
+				TeamId:               "team-1",
+				RecommendationClient: &stubRecommendationClient{listNodePolicyTargets: tt.listNodePolicyTargets},
+			}}
+			req, resp := newTestReadRequest(t, r, "target-1")
+			r.Read(context.Background(), req, resp)
+			if tt.removed {
+				assertRemovedFromState(t, resp)
+			} else {
+				assertKeptInState(t, resp)
+			}
+		})
+	}
+}
+
+func TestAccNodePolicyTargetResource(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Targets are not deleted on destroy, but deleting their node policy removes them.
+		CheckDestroy: testAccCheckDestroyed("devzero_node_policy_target", func(id string) bool {
+			return server.NodePolicyTarget(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNodePolicyTargetResourceConfig(server, "acc-node-target", true),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("devzero_node_policy_target.test", "id"),
+					tfresource.TestCheckResourceAttrPair("devzero_node_policy_target.test", "policy_id", "devzero_node_policy.test", "id"),
+					tfresource.TestCheckResourceAttrPair("devzero_node_policy_target.test", "cluster_ids.0", "devzero_cluster.test", "id"),
+					tfresource.TestCheckResourceAttr("devzero_node_policy_target.test", "name", "acc-node-target"),
+					tfresource.TestCheckResourceAttr("devzero_node_policy_target.test", "enabled", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "devzero_node_policy_target.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccNodePolicyTargetResourceConfig(server, "acc-node-target-updated", false),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_node_policy_target.test", "name", "acc-node-target-updated"),
+					tfresource.TestCheckResourceAttr("devzero_node_policy_target.test", "enabled", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccNodePolicyTargetResourceConfig(server *fakeapi.Server, name string, enabled bool) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "devzero_cluster" "test" {
+  name = "acc-cluster"
+}
+
+resource "devzero_node_policy" "test" {
+  name = "acc-node-policy"
+}
+
+resource "devzero_node_policy_target" "test" {
+  name        = %q
+  description = "Acceptance test target"
+  policy_id   = devzero_node_policy.test.id
+  cluster_ids = [devzero_cluster.test.id]
+  enabled     = %t
+}
+`, name, enabled)
+}
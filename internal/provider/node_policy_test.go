@@ -2,15 +2,25 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
+	"connectrpc.com/connect"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
 	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
 )
 
 func TestNodePolicyResourceSchema(t *testing.T) {
@@ -32,6 +42,56 @@ func TestNodePolicyResourceSchema(t *testing.T) {
 	validateNodePolicySchema(t, resp.Schema)
 }
 
+func TestNodePolicyResourceConfigValidators(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NodePolicyResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// configWith builds a config where every attribute is null except the
+	// given cloud provider blocks, which are set to empty objects.
+	configWith := func(providers ...string) tfsdk.Config {
+		objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		vals := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+		for name, typ := range objType.AttributeTypes {
+			vals[name] = tftypes.NewValue(typ, nil)
+		}
+		for _, name := range providers {
+			providerType := objType.AttributeTypes[name].(tftypes.Object)
+			inner := make(map[string]tftypes.Value, len(providerType.AttributeTypes))
+			for attrName, typ := range providerType.AttributeTypes {
+				inner[attrName] = tftypes.NewValue(typ, nil)
+			}
+			vals[name] = tftypes.NewValue(providerType, inner)
+		}
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, vals)}
+	}
+
+	validate := func(config tfsdk.Config) diag.Diagnostics {
+		var diags diag.Diagnostics
+		for _, v := range r.ConfigValidators(ctx) {
+			resp := &resource.ValidateConfigResponse{}
+			v.ValidateResource(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+			diags.Append(resp.Diagnostics...)
+		}
+		return diags
+	}
+
+	for _, provider := range []string{"aws", "azure", "gcp", "oci"} {
+		if diags := validate(configWith(provider)); diags.HasError() {
+			t.Errorf("Expected %s alone to be valid, got %v", provider, diags)
+		}
+	}
+	if diags := validate(configWith()); diags.HasError() {
+		t.Errorf("Expected no cloud provider block to be valid, got %v", diags)
+	}
+	if diags := validate(configWith("aws", "oci")); !diags.HasError() {
+		t.Error("Expected aws and oci together to be rejected")
+	}
+}
+
 func TestNodePolicyResourceModel(t *testing.T) {
 	t.Parallel()
 
@@ -970,6 +1030,360 @@ func TestNodePolicyResourceModel(t *testing.T) {
 		}
 	})
 
+	// Test GCP node class to proto
+	t.Run("GCPNodeClass_ToProto", func(t *testing.T) {
+		diskType := types.ObjectType{AttrTypes: map[string]attr.Type{
+			"size_gib":             types.Int32Type,
+			"category":             types.StringType,
+			"boot":                 types.BoolType,
+			"secondary_boot_image": types.StringType,
+			"secondary_boot_mode":  types.StringType,
+		}}
+		gcpConfig := &GCPNodeClass{
+			ServiceAccount: types.StringValue("nodes@my-project.iam.gserviceaccount.com"),
+			ImageSelectorTerms: types.ListValueMust(
+				types.ObjectType{AttrTypes: map[string]attr.Type{"alias": types.StringType, "id": types.StringType}},
+				[]attr.Value{types.ObjectValueMust(
+					map[string]attr.Type{"alias": types.StringType, "id": types.StringType},
+					map[string]attr.Value{"alias": types.StringValue("ContainerOptimizedOS@latest"), "id": types.StringNull()},
+				)},
+			),
+			ImageFamily: types.StringValue("ContainerOptimizedOS"),
+			KubeletConfiguration: &KubeletConfiguration{
+				MaxPods:                     types.Int32Value(64),
+				PodsPerCore:                 types.Int32Null(),
+				CpuCfsQuota:                 types.BoolNull(),
+				ClusterDns:                  types.ListNull(types.StringType),
+				SystemReserved:              types.MapNull(types.StringType),
+				KubeReserved:                types.MapNull(types.StringType),
+				EvictionHard:                types.MapNull(types.StringType),
+				EvictionSoft:                types.MapNull(types.StringType),
+				EvictionSoftGracePeriod:     types.MapNull(types.StringType),
+				EvictionMaxPodGracePeriod:   types.Int32Null(),
+				ImageGcHighThresholdPercent: types.Int32Null(),
+				ImageGcLowThresholdPercent:  types.Int32Null(),
+			},
+			Labels:      types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("platform")}),
+			Metadata:    types.MapNull(types.StringType),
+			NetworkTags: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("gke-nodes")}),
+			Disks: types.ListValueMust(diskType, []attr.Value{types.ObjectValueMust(
+				diskType.AttrTypes,
+				map[string]attr.Value{
+					"size_gib":             types.Int32Value(100),
+					"category":             types.StringValue("pd-balanced"),
+					"boot":                 types.BoolValue(true),
+					"secondary_boot_image": types.StringNull(),
+					"secondary_boot_mode":  types.StringNull(),
+				},
+			)}),
+		}
+		ctx := context.Background()
+		var diags diag.Diagnostics
+		proto := gcpConfig.toProto(ctx, &diags)
+		if diags.HasError() {
+			t.Fatalf("Expected no error, got %v", diags)
+		}
+		if proto.ServiceAccount != "nodes@my-project.iam.gserviceaccount.com" {
+			t.Errorf("Expected service account, got %s", proto.ServiceAccount)
+		}
+		if len(proto.ImageSelectorTerms) != 1 || proto.ImageSelectorTerms[0].Alias != "ContainerOptimizedOS@latest" {
+			t.Errorf("Expected 1 image selector term with alias, got %v", proto.ImageSelectorTerms)
+		}
+		if proto.ImageFamily == nil || *proto.ImageFamily != "ContainerOptimizedOS" {
+			t.Errorf("Expected ImageFamily=ContainerOptimizedOS, got %v", proto.ImageFamily)
+		}
+		if proto.KubeletConfiguration == nil || proto.KubeletConfiguration.MaxPods == nil || *proto.KubeletConfiguration.MaxPods != 64 {
+			t.Errorf("Expected kubelet MaxPods=64, got %v", proto.KubeletConfiguration)
+		}
+		if proto.Labels["team"] != "platform" {
+			t.Errorf("Expected label team=platform, got %v", proto.Labels)
+		}
+		if len(proto.NetworkTags) != 1 || proto.NetworkTags[0] != "gke-nodes" {
+			t.Errorf("Expected network tags [gke-nodes], got %v", proto.NetworkTags)
+		}
+		if len(proto.Disks) != 1 {
+			t.Fatalf("Expected 1 disk, got %d", len(proto.Disks))
+		}
+		if proto.Disks[0].SizeGib != 100 || proto.Disks[0].Category != "pd-balanced" || !proto.Disks[0].Boot {
+			t.Errorf("Unexpected disk: %v", proto.Disks[0])
+		}
+	})
+
+	// Test GCP node class from proto
+	t.Run("GCPNodeClass_FromProto", func(t *testing.T) {
+		family := "Ubuntu"
+		proto := &apiv1.GCPNodeClassSpec{
+			ServiceAccount: "nodes@my-project.iam.gserviceaccount.com",
+			ImageFamily:    &family,
+			Metadata:       map[string]string{"block-project-ssh-keys": "true"},
+			Disks: []*apiv1.GCPDisk{
+				{SizeGib: 200, Category: "pd-ssd", Boot: true},
+				{Category: "local-ssd"},
+			},
+		}
+		gcp := gcpNodeClassFromProto(proto)
+		if gcp.ServiceAccount.ValueString() != "nodes@my-project.iam.gserviceaccount.com" {
+			t.Errorf("Expected service account, got %s", gcp.ServiceAccount.ValueString())
+		}
+		if gcp.ImageFamily.ValueString() != "Ubuntu" {
+			t.Errorf("Expected image_family=Ubuntu, got %s", gcp.ImageFamily.ValueString())
+		}
+		if !gcp.ImageSelectorTerms.IsNull() {
+			t.Error("Expected image_selector_terms to be null")
+		}
+		if !gcp.Labels.IsNull() {
+			t.Error("Expected labels to be null")
+		}
+		if gcp.KubeletConfiguration != nil {
+			t.Error("Expected kubelet_configuration to be nil")
+		}
+		if len(gcp.Metadata.Elements()) != 1 {
+			t.Errorf("Expected 1 metadata entry, got %d", len(gcp.Metadata.Elements()))
+		}
+		disks := gcp.Disks.Elements()
+		if len(disks) != 2 {
+			t.Fatalf("Expected 2 disks, got %d", len(disks))
+		}
+		second := disks[1].(types.Object).Attributes()
+		if !second["size_gib"].IsNull() {
+			t.Error("Expected unset size_gib to be null")
+		}
+		if second["boot"].(types.Bool).ValueBool() {
+			t.Error("Expected second disk boot=false")
+		}
+	})
+
+	// Test GCP empty spec detection
+	t.Run("IsGCPSpecEmpty", func(t *testing.T) {
+		if !isGCPSpecEmpty(nil) {
+			t.Error("Expected nil spec to be empty")
+		}
+		if !isGCPSpecEmpty(&apiv1.GCPNodeClassSpec{}) {
+			t.Error("Expected zero-value spec to be empty")
+		}
+		if isGCPSpecEmpty(&apiv1.GCPNodeClassSpec{NetworkTags: []string{"gke-nodes"}}) {
+			t.Error("Expected spec with network tags to be non-empty")
+		}
+	})
+
+	// Test OCI node class to proto
+	t.Run("OCINodeClass_ToProto", func(t *testing.T) {
+		selectorType := types.ObjectType{AttrTypes: map[string]attr.Type{
+			"id":   types.StringType,
+			"name": types.StringType,
+		}}
+		blockDeviceType := types.ObjectType{AttrTypes: map[string]attr.Type{
+			"size_in_gbs": types.Int64Type,
+			"vpus_per_gb": types.Int64Type,
+		}}
+		ociConfig := &OCINodeClass{
+			VcnId:         types.StringValue("ocid1.vcn.oc1..example"),
+			ImageSelector: types.ListNull(types.ObjectType{AttrTypes: map[string]attr.Type{}}),
+			SubnetSelector: types.ListValueMust(selectorType, []attr.Value{types.ObjectValueMust(
+				selectorType.AttrTypes,
+				map[string]attr.Value{"id": types.StringNull(), "name": types.StringValue("oke-workers")},
+			)}),
+			SecurityGroupSelector: types.ListNull(selectorType),
+			UserData:              types.StringNull(),
+			PreInstallScript:      types.StringValue("#!/bin/bash\necho hello"),
+			MetaData:              types.MapNull(types.StringType),
+			ImageFamily:           types.StringValue("OracleLinux"),
+			Tags:                  types.MapNull(types.StringType),
+			FreeFormTags:          types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("platform")}),
+			BootConfig: &OCIBootConfig{
+				BootVolumeSizeInGbs: types.Int64Value(100),
+				BootVolumeVpusPerGb: types.Int64Null(),
+			},
+			LaunchOptions: &OCILaunchOptions{
+				BootVolumeType:                  types.StringNull(),
+				Firmware:                        types.StringValue("UEFI_64"),
+				NetworkType:                     types.StringValue("VFIO"),
+				RemoteDataVolumeType:            types.StringNull(),
+				IsConsistentVolumeNamingEnabled: types.BoolValue(true),
+			},
+			BlockDevices: types.ListValueMust(blockDeviceType, []attr.Value{types.ObjectValueMust(
+				blockDeviceType.AttrTypes,
+				map[string]attr.Value{"size_in_gbs": types.Int64Value(200), "vpus_per_gb": types.Int64Value(20)},
+			)}),
+			AgentList: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Bastion")}),
+		}
+		ctx := context.Background()
+		var diags diag.Diagnostics
+		proto := ociConfig.toProto(ctx, &diags)
+		if diags.HasError() {
+			t.Fatalf("Expected no error, got %v", diags)
+		}
+		if proto.VcnId != "ocid1.vcn.oc1..example" {
+			t.Errorf("Expected VcnId, got %s", proto.VcnId)
+		}
+		if len(proto.SubnetSelector) != 1 || proto.SubnetSelector[0].Name != "oke-workers" {
+			t.Errorf("Expected subnet selector by name, got %v", proto.SubnetSelector)
+		}
+		if proto.UserData != nil {
+			t.Errorf("Expected nil UserData, got %v", *proto.UserData)
+		}
+		if proto.PreInstallScript == nil {
+			t.Error("Expected non-nil PreInstallScript")
+		}
+		if proto.FreeFormTags["team"] != "platform" {
+			t.Errorf("Expected free-form tag team=platform, got %v", proto.FreeFormTags)
+		}
+		if proto.BootConfig == nil || proto.BootConfig.BootVolumeSizeInGbs != 100 {
+			t.Errorf("Expected BootVolumeSizeInGbs=100, got %v", proto.BootConfig)
+		}
+		if proto.LaunchOptions == nil || proto.LaunchOptions.GetFirmware() != "UEFI_64" || proto.LaunchOptions.BootVolumeType != nil {
+			t.Errorf("Unexpected launch options: %v", proto.LaunchOptions)
+		}
+		if len(proto.BlockDevices) != 1 || proto.BlockDevices[0].SizeInGbs != 200 || proto.BlockDevices[0].VpusPerGb != 20 {
+			t.Errorf("Unexpected block devices: %v", proto.BlockDevices)
+		}
+		if len(proto.AgentList) != 1 || proto.AgentList[0] != "Bastion" {
+			t.Errorf("Expected agent list [Bastion], got %v", proto.AgentList)
+		}
+	})
+
+	// Test OCI node class from proto
+	t.Run("OCINodeClass_FromProto", func(t *testing.T) {
+		userData := "#cloud-config"
+		proto := &apiv1.OCINodeClassSpec{
+			VcnId: "ocid1.vcn.oc1..example",
+			ImageSelector: []*apiv1.OCIImageSelectorTerm{
+				{Name: "Oracle-Linux-8.9", CompartmentId: "ocid1.compartment.oc1..example"},
+			},
+			UserData:   &userData,
+			BootConfig: &apiv1.OCIBootConfig{BootVolumeSizeInGbs: 100},
+		}
+		oci := ociNodeClassFromProto(proto)
+		if oci.VcnId.ValueString() != "ocid1.vcn.oc1..example" {
+			t.Errorf("Expected vcn_id, got %s", oci.VcnId.ValueString())
+		}
+		if !oci.ImageFamily.IsNull() {
+			t.Error("Expected empty image_family to be null")
+		}
+		if oci.UserData.ValueString() != "#cloud-config" {
+			t.Errorf("Expected user_data, got %s", oci.UserData.ValueString())
+		}
+		terms := oci.ImageSelector.Elements()
+		if len(terms) != 1 {
+			t.Fatalf("Expected 1 image selector term, got %d", len(terms))
+		}
+		termAttrs := terms[0].(types.Object).Attributes()
+		if !termAttrs["id"].IsNull() {
+			t.Error("Expected empty image id to be null")
+		}
+		if oci.BootConfig == nil || oci.BootConfig.BootVolumeSizeInGbs.ValueInt64() != 100 {
+			t.Errorf("Expected boot_volume_size_in_gbs=100, got %v", oci.BootConfig)
+		}
+		if !oci.BootConfig.BootVolumeVpusPerGb.IsNull() {
+			t.Error("Expected unset boot_volume_vpus_per_gb to be null")
+		}
+		if oci.LaunchOptions != nil {
+			t.Error("Expected launch_options to be nil")
+		}
+		if !oci.SubnetSelector.IsNull() || !oci.BlockDevices.IsNull() || !oci.AgentList.IsNull() {
+			t.Error("Expected unset lists to be null")
+		}
+	})
+
+	// Test OCI empty spec detection
+	t.Run("IsOCISpecEmpty", func(t *testing.T) {
+		if !isOCISpecEmpty(nil) {
+			t.Error("Expected nil spec to be empty")
+		}
+		if !isOCISpecEmpty(&apiv1.OCINodeClassSpec{}) {
+			t.Error("Expected zero-value spec to be empty")
+		}
+		if isOCISpecEmpty(&apiv1.OCINodeClassSpec{AgentList: []string{"Bastion"}}) {
+			t.Error("Expected spec with agent list to be non-empty")
+		}
+	})
+
+	// Test startup taints round trip
+	t.Run("StartupTaints_RoundTrip", func(t *testing.T) {
+		taints := []*apiv1.Taint{
+			{Key: "node.cilium.io/agent-not-ready", Value: "true", Effect: "NoExecute"},
+		}
+		list := taintsFromProto(taints)
+		if len(list.Elements()) != 1 {
+			t.Fatalf("Expected 1 taint, got %d", len(list.Elements()))
+		}
+		var diags diag.Diagnostics
+		proto := taintsToProto(context.Background(), &diags, "startup_taints", list)
+		if diags.HasError() {
+			t.Fatalf("Expected no error, got %v", diags)
+		}
+		if len(proto) != 1 || proto[0].Key != "node.cilium.io/agent-not-ready" || proto[0].Effect != "NoExecute" {
+			t.Errorf("Unexpected taints after round trip: %v", proto)
+		}
+		if !taintsFromProto(nil).IsNull() {
+			t.Error("Expected no taints to convert to a null list")
+		}
+	})
+
+	// Test taints that cannot be converted are reported rather than dropped
+	t.Run("Taints_Unknown", func(t *testing.T) {
+		taintType := taintsFromProto(nil).ElementType(context.Background()).(types.ObjectType)
+		unknown := types.ListValueMust(taintType, []attr.Value{types.ObjectUnknown(taintType.AttrTypes)})
+
+		var diags diag.Diagnostics
+		if proto := taintsToProto(context.Background(), &diags, "taints", unknown); proto != nil || !diags.HasError() {
+			t.Errorf("Expected a conversion error, got %v and %v", proto, diags)
+		}
+	})
+
+	// Test new policy fields are read back from proto
+	t.Run("StartupTaintsZonalShiftLocalNvme_FromProto", func(t *testing.T) {
+		startupTip := "Removed once the CNI is ready"
+		nvmeTip := "Local NVMe in GiB"
+		policy := &apiv1.NodePolicy{
+			StartupTaints: []*apiv1.Taint{
+				{Key: "node.cilium.io/agent-not-ready", Value: "true", Effect: "NoExecute"},
+			},
+			StartupTaintsTip: &startupTip,
+			ZonalShift: &apiv1.ZonalShiftConfig{
+				RespectZonalShift:  true,
+				EvictImpactedNodes: true,
+			},
+			InstanceLocalNvme: &apiv1.LabelSelector{
+				MatchExpressions: []*apiv1.LabelSelectorRequirement{
+					{Key: "karpenter.k8s.aws/instance-local-nvme", Operator: apiv1.LabelSelectorOperator_LABEL_SELECTOR_OPERATOR_GT, Values: []string{"100"}},
+				},
+			},
+			InstanceLocalNvmeTip: &nvmeTip,
+		}
+		var m NodePolicyResourceModel
+		m.fromProto(policy)
+		if len(m.StartupTaints.Elements()) != 1 {
+			t.Errorf("Expected 1 startup taint, got %d", len(m.StartupTaints.Elements()))
+		}
+		if !m.Taints.IsNull() {
+			t.Error("Expected taints to be null")
+		}
+		if m.StartupTaintsTip.ValueString() != startupTip {
+			t.Errorf("Expected startup_taints_tip %q, got %q", startupTip, m.StartupTaintsTip.ValueString())
+		}
+		if m.ZonalShift == nil {
+			t.Fatal("Expected non-nil zonal_shift")
+		}
+		if !m.ZonalShift.RespectZonalShift.ValueBool() || !m.ZonalShift.EvictImpactedNodes.ValueBool() || m.ZonalShift.AllowZoneFallback.ValueBool() {
+			t.Errorf("Unexpected zonal_shift: %+v", m.ZonalShift)
+		}
+		if m.InstanceLocalNvme == nil || len(m.InstanceLocalNvme.MatchExpressions.Elements()) != 1 {
+			t.Errorf("Expected instance_local_nvme with 1 match expression, got %+v", m.InstanceLocalNvme)
+		}
+		if m.InstanceLocalNvmeTip.ValueString() != nvmeTip {
+			t.Errorf("Expected instance_local_nvme_tip %q, got %q", nvmeTip, m.InstanceLocalNvmeTip.ValueString())
+		}
+
+		// A zonal shift config with every option disabled is treated as unset.
+		policy.ZonalShift = &apiv1.ZonalShiftConfig{}
+		m = NodePolicyResourceModel{}
+		m.fromProto(policy)
+		if m.ZonalShift != nil {
+			t.Error("Expected disabled zonal_shift to be nil")
+		}
+	})
+
 	// Test instance_types via LabelSelector conversion
 	t.Run("InstanceTypes_LabelSelector_ToProto", func(t *testing.T) {
 		ctx := context.Background()
@@ -1038,11 +1452,12 @@ func validateNodePolicySchema(t *testing.T, schema schema.Schema) {
 		"description", "weight",
 		"instance_categories", "instance_families", "instance_cpus",
 		"instance_hypervisors", "instance_generations", "instance_sizes",
-		"instance_types",
+		"instance_types", "instance_local_nvme",
 		"zones", "architectures", "capacity_types", "operating_systems",
-		"labels", "taints", "disruption", "limits",
+		"labels", "taints", "startup_taints", "zonal_shift", "disruption", "limits",
 		"node_pool_name", "node_class_name",
-		"aws", "azure", "raw",
+		"aws", "azure", "gcp", "oci", "raw",
+		"retain_on_destroy",
 	}
 	for _, attr := range optionalAttrs {
 		if _, exists := schema.Attributes[attr]; !exists {
@@ -1055,6 +1470,7 @@ func validateNodePolicySchema(t *testing.T, schema schema.Schema) {
 		"instance_categories_tip", "instance_families_tip", "instance_cpus_tip",
 		"zones_tip", "architectures_tip", "capacity_type_tip", "operating_systems_tip",
 		"taints_tip", "disruptions_tip", "limits_tip",
+		"instance_local_nvme_tip", "startup_taints_tip",
 	}
 	for _, attr := range tooltipAttrs {
 		if _, exists := schema.Attributes[attr]; !exists {
@@ -1070,4 +1486,167 @@ func validateNodePolicySchema(t *testing.T, schema schema.Schema) {
 	if _, exists := schema.Attributes["azure"]; !exists {
 		t.Error("Azure configuration not found in schema")
 	}
+
+	// retain_on_destroy must default to false so destroy deletes the policy
+	if retainAttr, exists := schema.Attributes["retain_on_destroy"]; exists {
+		if !retainAttr.IsOptional() || !retainAttr.IsComputed() {
+			t.Error("retain_on_destroy should be optional and computed")
+		}
+	}
+}
+
+func TestNodePolicyResourceRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		listNodePolicies func(context.Context, *connect.Request[apiv1.ListNodePoliciesRequest]) (*connect.Response[apiv1.ListNodePoliciesResponse], error)
+		removed          bool
+	}{
+		"not in list": {
+			listNodePolicies: func(context.Context, *connect.Request[apiv1.ListNodePoliciesRequest]) (*connect.Response[apiv1.ListNodePoliciesResponse], error) {
+				return connect.NewResponse(&apiv1.ListNodePoliciesResponse{Policies: []*apiv1.NodePolicy{{Id: "policy-2"}}}), nil
+			},
+			removed: true,
+		},
+		"empty list": {
+			listNodePolicies: func(context.Context, *connect.Request[apiv1.ListNodePoliciesRequest]) (*connect.Response[apiv1.ListNodePoliciesResponse], error) {
+				return connect.NewResponse(&apiv1.ListNodePoliciesResponse{}), nil
+			},
+			removed: true,
+		},
+		"unavailable": {
+			listNodePolicies: func(context.Context, *connect.Request[apiv1.ListNodePoliciesRequest]) (*connect.Response[apiv1.ListNodePoliciesResponse], error) {
+				return nil, connect.NewError(connect.CodeUnavailable, errors.New("service unavailable"))
+			},
+			removed: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &NodePolicyResource{client: &ClientSet{
+				TeamId:               "team-1",
+				RecommendationClient: &stubRecommendationClient{listNodePolicies: tt.listNodePolicies},
+			}}
+			req, resp := newTestReadRequest(t, r, "policy-1")
+			r.Read(context.Background(), req, resp)
+			if tt.removed {
+				assertRemovedFromState(t, resp)
+			} else {
+				assertKeptInState(t, resp)
+			}
+		})
+	}
+}
+
+func TestNodePolicyResourceDelete_RetainOnDestroy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeapi.NewServer(t)
+	client := &ClientSet{
+		TeamId:               "team-1",
+		RecommendationClient: apiv1connect.NewK8SRecommendationServiceClient(http.DefaultClient, server.URL),
+	}
+
+	tests := map[string]bool{
+		"delete policy": false,
+		"retain policy": true,
+	}
+
+	for name, retain := range tests {
+		t.Run(name, func(t *testing.T) {
+			createPoliciesResp, err := client.RecommendationClient.CreateNodePolicies(ctx, connect.NewRequest(&apiv1.CreateNodePoliciesRequest{
+				TeamId:   "team-1",
+				Policies: []*apiv1.NodePolicy{{Name: "policy"}},
+			}))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			policyID := createPoliciesResp.Msg.Policies[0].Id
+
+			r := &NodePolicyResource{client: client}
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			state.SetAttribute(ctx, path.Root("id"), policyID)
+			state.SetAttribute(ctx, path.Root("retain_on_destroy"), retain)
+
+			resp := &resource.DeleteResponse{State: state}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+
+			if kept := server.NodePolicy(policyID) != nil; kept != retain {
+				t.Errorf("Expected node policy kept %t, got %t", retain, kept)
+			}
+		})
+	}
+}
+
+func TestAccNodePolicyResource(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_node_policy", func(id string) bool {
+			return server.NodePolicy(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNodePolicyResourceConfig(server, "acc-node-policy", 10),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("devzero_node_policy.test", "id"),
+					tfresource.TestCheckResourceAttr("devzero_node_policy.test", "name", "acc-node-policy"),
+					tfresource.TestCheckResourceAttr("devzero_node_policy.test", "team_id", testAccTeamID),
+					tfresource.TestCheckResourceAttr("devzero_node_policy.test", "weight", "10"),
+					tfresource.TestCheckResourceAttr("devzero_node_policy.test", "aws.role", "KarpenterNodeRole-acc"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "devzero_node_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState by name testing
+			{
+				ResourceName:      "devzero_node_policy.test",
+				ImportState:       true,
+				ImportStateId:     "name:acc-node-policy",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccNodePolicyResourceConfig(server, "acc-node-policy-updated", 20),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_node_policy.test", "name", "acc-node-policy-updated"),
+					tfresource.TestCheckResourceAttr("devzero_node_policy.test", "weight", "20"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccNodePolicyResourceConfig(server *fakeapi.Server, name string, weight int) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "devzero_node_policy" "test" {
+  name            = %q
+  node_pool_name  = "acc-pool"
+  node_class_name = "acc-class"
+  weight          = %d
+
+  labels = {
+    "workload-type" = "general"
+  }
+
+  aws = {
+    role = "KarpenterNodeRole-acc"
+  }
+}
+`, name, weight)
 }
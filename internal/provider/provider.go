@@ -2,16 +2,24 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	apiv1connect "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
@@ -19,14 +27,27 @@ import (
 
 type ClientSet struct {
 	TeamId                string
+	DefaultTags           []string
 	ClusterMutationClient apiv1connect.ClusterMutationServiceClient
 	ClusterServiceClient  apiv1connect.ClusterServiceClient
 	K8SServiceClient      apiv1connect.K8SServiceClient
 	RecommendationClient  apiv1connect.K8SRecommendationServiceClient
+
+	// listCache is shared by all clients so that a mutation through any of them invalidates it.
+	listCache *listCache
+}
+
+// teamID returns the team ID set on a resource, falling back to the provider team ID.
+func (c *ClientSet) teamID(value types.String) string {
+	if !value.IsNull() && !value.IsUnknown() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+	return c.TeamId
 }
 
 // Ensure DevzeroProvider satisfies various provider interfaces.
 var _ provider.Provider = &DevzeroProvider{}
+var _ provider.ProviderWithEphemeralResources = &DevzeroProvider{}
 
 // DevzeroProvider defines the provider implementation.
 type DevzeroProvider struct {
@@ -39,9 +60,33 @@ type DevzeroProvider struct {
 
 // DevzeroProviderModel describes the provider data model.
 type DevzeroProviderModel struct {
-	URL    types.String `tfsdk:"url"`
-	TeamId types.String `tfsdk:"team_id"`
-	Token  types.String `tfsdk:"token"`
+	URL                   types.String  `tfsdk:"url"`
+	TeamId                types.String  `tfsdk:"team_id"`
+	Token                 types.String  `tfsdk:"token"`
+	DefaultTags           types.Set     `tfsdk:"default_tags"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	RetryMinBackoff       types.String  `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff       types.String  `tfsdk:"retry_max_backoff"`
+	MaxRequestsPerSecond  types.Float64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	ListCacheTTL          types.String  `tfsdk:"list_cache_ttl"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+
+	Profile          types.String `tfsdk:"profile"`
+	SharedConfigFile types.String `tfsdk:"shared_config_file"`
+
+	Protocol types.String `tfsdk:"protocol"`
+	Codec    types.String `tfsdk:"codec"`
+
+	DebugRPC        types.Bool   `tfsdk:"debug_rpc"`
+	UserAgentSuffix types.String `tfsdk:"user_agent_suffix"`
 }
 
 func (p *DevzeroProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -51,6 +96,7 @@ func (p *DevzeroProvider) Metadata(ctx context.Context, req provider.MetadataReq
 
 func (p *DevzeroProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "The Devzero provider manages clusters and optimization policies in [Devzero](https://www.devzero.io).\n\n`url`, `team_id` and `token` each take the first non-empty value among, in order of precedence:\n\n1. the provider arguments,\n2. the `DEVZERO_URL`, `DEVZERO_TEAM_ID` and `DEVZERO_TOKEN` environment variables,\n3. the profile selected with `profile` or `DEVZERO_PROFILE`, or the `default` profile, of the shared credentials file `~/.devzero/credentials` (or `shared_config_file`).\n\n`url` finally defaults to `https://dakr.devzero.io`. The credentials file uses an INI format:\n\n```ini\n[default]\nteam_id = <YOUR_TEAM_ID>\ntoken   = <YOUR_TOKEN>\n\n[staging]\nurl     = https://dakr.staging.example.com\nteam_id = <STAGING_TEAM_ID>\ntoken   = <STAGING_TOKEN>\n```",
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
 				MarkdownDescription: "Devzero API URL",
@@ -65,6 +111,109 @@ func (p *DevzeroProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"default_tags": schema.SetAttribute{
+				MarkdownDescription: "Tags added to every cluster managed by the provider, in addition to the cluster `tags`. The combined tags are exposed in the cluster `tags_all` attribute.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile in the shared credentials file to read `url`, `team_id` and `token` from. Can also be set with the `DEVZERO_PROFILE` environment variable. Values of the profile are overridden by provider arguments and by the `DEVZERO_URL`, `DEVZERO_TEAM_ID` and `DEVZERO_TOKEN` environment variables. Defaults to `default`.",
+				Optional:            true,
+			},
+			"shared_config_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the shared credentials file. Can also be set with the `DEVZERO_SHARED_CONFIG_FILE` environment variable. Defaults to `~/.devzero/credentials`.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a read-only API call (`Get*`/`List*`) is retried after a transient error (`Unavailable`, `ResourceExhausted` or `DeadlineExceeded`). Set to `0` to disable retries. Defaults to `3`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of API requests per second across all resources, with bursts of up to one second worth of requests. Retries count as separate requests. Set to `0` for no limit. Defaults to no limit.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at the same time across all resources. Set to `0` for no limit. Defaults to no limit.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"list_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the responses of `List*` API calls are reused, as a duration string (e.g. `30s`, `5m`). Node policies and node policy targets can only be read by listing every object of the team, so caching the list makes a refresh cost one call per resource type instead of one per resource. Creating, updating or deleting any resource invalidates the cache. Set to `0s` to disable caching. Defaults to `1m`.",
+				Optional:            true,
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				MarkdownDescription: "Delay before the first retry, as a duration string (e.g. `500ms`, `2s`). The delay doubles on every attempt and is jittered. Defaults to `1s`.",
+				Optional:            true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: "Upper bound for the delay between retries, as a duration string (e.g. `30s`, `1m`). Defaults to `30s`.",
+				Optional:            true,
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "Wire protocol used to talk to the Devzero API: `grpc`, `grpcweb` or `connect`. `grpc` requires HTTP/2 end-to-end; use `grpcweb` or `connect` behind proxies that only speak HTTP/1.1. Can also be set with the `DEVZERO_PROTOCOL` environment variable. Defaults to `grpc`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(supportedProtocols...),
+				},
+			},
+			"codec": schema.StringAttribute{
+				MarkdownDescription: "Message encoding used on the wire: `proto` (binary) or `json`. Can also be set with the `DEVZERO_CODEC` environment variable. Defaults to `proto`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(supportedCodecs...),
+				},
+			},
+			"debug_rpc": schema.BoolAttribute{
+				MarkdownDescription: "Log every Devzero API call: the RPC name, duration and status code at `DEBUG`, and the request and response bodies at `TRACE`. Tokens and `user_data` are masked. Log output is controlled by `TF_LOG`/`TF_LOG_PROVIDER`. Defaults to `true` when the `TF_LOG_PROVIDER_DEVZERO` environment variable is set.",
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the `User-Agent` header sent with every API request, e.g. the name of the CI pipeline running Terraform. Can also be set with the `DEVZERO_USER_AGENT_SUFFIX` environment variable.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates trusted in addition to the system roots when connecting to the Devzero API. Can also be set with the `DEVZERO_CA_CERT_PEM` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with PEM-encoded CA certificates trusted in addition to the system roots when connecting to the Devzero API. Can also be set with the `DEVZERO_CA_CERT_FILE` environment variable.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client certificate, or the path to one, used for mutual TLS. Requires `client_key`. Can also be set with the `DEVZERO_CLIENT_CERT` environment variable.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded private key for `client_cert`, or the path to one. Can also be set with the `DEVZERO_CLIENT_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the Devzero API server certificate. Only use this for testing. Can also be set with the `DEVZERO_INSECURE_SKIP_VERIFY` environment variable.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP(S) proxy used to reach the Devzero API (e.g. `http://proxy.example.com:3128`). Can also be set with the `DEVZERO_PROXY_URL` environment variable. Defaults to the standard `HTTPS_PROXY`/`NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout for a single API request, as a duration string (e.g. `30s`, `2m`). Can also be set with the `DEVZERO_REQUEST_TIMEOUT` environment variable. Defaults to no timeout.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -106,28 +255,50 @@ func (p *DevzeroProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if data.DefaultTags.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_tags"),
+			"Unknown Devzero Default Tags",
+			"The provider cannot apply default tags to clusters as there is an unknown configuration value for default_tags. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Default to environment variables, but override with Terraform configuration
-
-	url := os.Getenv("DEVZERO_URL")
-	teamId := os.Getenv("DEVZERO_TEAM_ID")
-	token := os.Getenv("DEVZERO_TOKEN")
-
-	if !data.URL.IsNull() {
-		url = data.URL.ValueString()
+	defaultTags, err := getStringList(ctx, data.DefaultTags.Elements())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_tags"),
+			"Invalid Devzero Default Tags",
+			fmt.Sprintf("The provider cannot read default_tags: %s", err),
+		)
+		return
 	}
 
-	if !data.TeamId.IsNull() {
-		teamId = data.TeamId.ValueString()
-	}
+	// Resolve the credentials from the Terraform configuration, environment variables and shared credentials profile
 
-	if !data.Token.IsNull() {
-		token = data.Token.ValueString()
+	creds, err := resolveCredentials(data)
+	if err != nil {
+		attribute := "profile"
+		var credsErr *credentialsError
+		if errors.As(err, &credsErr) {
+			attribute = credsErr.attribute
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Devzero Credentials",
+			fmt.Sprintf("The provider cannot resolve the Devzero credentials: %s", err),
+		)
+		return
 	}
 
+	url := creds.url
+	teamId := creds.teamID
+	token := creds.token
+
 	// If any of the expected configurations are missing, then return errors or set the defaults values
 
 	if url == "" {
@@ -139,7 +310,7 @@ func (p *DevzeroProvider) Configure(ctx context.Context, req provider.ConfigureR
 			path.Root("team_id"),
 			"Missing Devzero Team ID",
 			"The provider cannot create the Devzero API client as there is no Devzero Team ID. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the DEVZERO_TEAM_ID environment variable.",
+				"Either target apply the source of the value first, set the value statically in the configuration, use the DEVZERO_TEAM_ID environment variable, or set team_id in a credentials profile.",
 		)
 	}
 
@@ -148,62 +319,196 @@ func (p *DevzeroProvider) Configure(ctx context.Context, req provider.ConfigureR
 			path.Root("token"),
 			"Missing Devzero API Token",
 			"The provider cannot create the Devzero API client as there is no Devzero API Token. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the DEVZERO_TOKEN environment variable."+
+				"Either target apply the source of the value first, set the value statically in the configuration, use the DEVZERO_TOKEN environment variable, or set token in a credentials profile. "+
 				"If either is already set, ensure the value is not empty."+
 				"For more information, see the [Devzero documentation](https://www.devzero.io/docs/platform/admin/personal-access-tokens).",
 		)
 	}
 
+	retry := retryPolicy{
+		maxRetries: defaultMaxRetries,
+		minBackoff: defaultRetryMinBackoff,
+		maxBackoff: defaultRetryMaxBackoff,
+	}
+
+	if !data.MaxRetries.IsNull() && !data.MaxRetries.IsUnknown() {
+		retry.maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	if !data.RetryMinBackoff.IsNull() && !data.RetryMinBackoff.IsUnknown() {
+		d, err := time.ParseDuration(data.RetryMinBackoff.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_min_backoff"),
+				"Invalid Retry Backoff",
+				fmt.Sprintf("The retry_min_backoff value %q is not a valid non-negative duration (e.g. \"500ms\", \"2s\").", data.RetryMinBackoff.ValueString()),
+			)
+		}
+		retry.minBackoff = d
+	}
+
+	if !data.RetryMaxBackoff.IsNull() && !data.RetryMaxBackoff.IsUnknown() {
+		d, err := time.ParseDuration(data.RetryMaxBackoff.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Invalid Retry Backoff",
+				fmt.Sprintf("The retry_max_backoff value %q is not a valid non-negative duration (e.g. \"30s\", \"1m\").", data.RetryMaxBackoff.ValueString()),
+			)
+		}
+		retry.maxBackoff = d
+	}
+
+	listCacheTTL := defaultListCacheTTL
+	if !data.ListCacheTTL.IsNull() && !data.ListCacheTTL.IsUnknown() {
+		d, err := time.ParseDuration(data.ListCacheTTL.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("list_cache_ttl"),
+				"Invalid List Cache TTL",
+				fmt.Sprintf("The list_cache_ttl value %q is not a valid non-negative duration (e.g. \"30s\", \"5m\").", data.ListCacheTTL.ValueString()),
+			)
+		}
+		listCacheTTL = d
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client := http.DefaultClient
+	if retry.minBackoff > retry.maxBackoff {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_min_backoff"),
+			"Invalid Retry Backoff",
+			fmt.Sprintf("retry_min_backoff (%s) must not be greater than retry_max_backoff (%s).", retry.minBackoff, retry.maxBackoff),
+		)
+		return
+	}
+
+	transport := transportConfig{
+		caCertPEM:  stringValueOrEnv(data.CACertPEM, "DEVZERO_CA_CERT_PEM"),
+		caCertFile: stringValueOrEnv(data.CACertFile, "DEVZERO_CA_CERT_FILE"),
+		clientCert: stringValueOrEnv(data.ClientCert, "DEVZERO_CLIENT_CERT"),
+		clientKey:  stringValueOrEnv(data.ClientKey, "DEVZERO_CLIENT_KEY"),
+		proxyURL:   stringValueOrEnv(data.ProxyURL, "DEVZERO_PROXY_URL"),
+	}
 
-	authInterceptor := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
-		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-			req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
-			return next(ctx, req)
+	if !data.InsecureSkipVerify.IsNull() && !data.InsecureSkipVerify.IsUnknown() {
+		transport.insecureSkipVerify = data.InsecureSkipVerify.ValueBool()
+	} else if v := os.Getenv("DEVZERO_INSECURE_SKIP_VERIFY"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid Insecure Skip Verify",
+				fmt.Sprintf("The DEVZERO_INSECURE_SKIP_VERIFY environment variable value %q is not a valid boolean.", v),
+			)
 		}
-	})
+		transport.insecureSkipVerify = b
+	}
+
+	if v := stringValueOrEnv(data.RequestTimeout, "DEVZERO_REQUEST_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("The request_timeout value %q is not a valid non-negative duration (e.g. \"30s\", \"2m\").", v),
+			)
+		}
+		transport.requestTimeout = d
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := newHTTPClient(transport)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Transport Configuration",
+			fmt.Sprintf("The provider cannot create the Devzero API client: %s", err),
+		)
+		return
+	}
+
+	opts, err := protocolOptions(
+		stringValueOrEnv(data.Protocol, "DEVZERO_PROTOCOL"),
+		stringValueOrEnv(data.Codec, "DEVZERO_CODEC"),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Protocol Configuration",
+			fmt.Sprintf("The provider cannot create the Devzero API client: %s", err),
+		)
+		return
+	}
+
+	var requestsPerSecond float64
+	if !data.MaxRequestsPerSecond.IsNull() && !data.MaxRequestsPerSecond.IsUnknown() {
+		requestsPerSecond = data.MaxRequestsPerSecond.ValueFloat64()
+	}
+
+	var maxConcurrent int
+	if !data.MaxConcurrentRequests.IsNull() && !data.MaxConcurrentRequests.IsUnknown() {
+		maxConcurrent = int(data.MaxConcurrentRequests.ValueInt64())
+	}
+
+	// The limiter sits inside the retry interceptor so that every attempt is throttled
+	limiter := newRequestLimiter(requestsPerSecond, maxConcurrent)
+
+	// The list cache sits outside the retry interceptor and the limiter so that cache hits skip both
+	listCache := newListCache(listCacheTTL)
+
+	interceptors := []connect.Interceptor{
+		newMetadataInterceptor(userAgent(p.version, req.TerraformVersion, stringValueOrEnv(data.UserAgentSuffix, "DEVZERO_USER_AGENT_SUFFIX"))),
+		listCache.interceptor(),
+		newRetryInterceptor(retry),
+		limiter.interceptor(),
+		newAuthInterceptor(token),
+	}
+
+	debugRPC := os.Getenv("TF_LOG_PROVIDER_DEVZERO") != ""
+	if !data.DebugRPC.IsNull() && !data.DebugRPC.IsUnknown() {
+		debugRPC = data.DebugRPC.ValueBool()
+	}
+	if debugRPC {
+		// Logging runs after authentication so the masked Authorization header is visible.
+		interceptors = append(interceptors, newLoggingInterceptor())
+	}
+
+	opts = append(opts, connect.WithInterceptors(interceptors...))
 
 	// Create the Devzero API client
 	clientset := &ClientSet{
-		TeamId: teamId,
-		ClusterMutationClient: apiv1connect.NewClusterMutationServiceClient(
-			client,
-			url,
-			connect.WithGRPC(),
-			connect.WithInterceptors(authInterceptor),
-		),
-		ClusterServiceClient: apiv1connect.NewClusterServiceClient(
-			client,
-			url,
-			connect.WithGRPC(),
-			connect.WithInterceptors(authInterceptor),
-		),
-		K8SServiceClient: apiv1connect.NewK8SServiceClient(
-			client,
-			url,
-			connect.WithGRPC(),
-			connect.WithInterceptors(authInterceptor),
-		),
-		RecommendationClient: apiv1connect.NewK8SRecommendationServiceClient(
-			client,
-			url,
-			connect.WithGRPC(),
-			connect.WithInterceptors(authInterceptor),
-		),
+		TeamId:                teamId,
+		DefaultTags:           defaultTags,
+		ClusterMutationClient: apiv1connect.NewClusterMutationServiceClient(client, url, opts...),
+		ClusterServiceClient:  apiv1connect.NewClusterServiceClient(client, url, opts...),
+		K8SServiceClient:      apiv1connect.NewK8SServiceClient(client, url, opts...),
+		RecommendationClient:  apiv1connect.NewK8SRecommendationServiceClient(client, url, opts...),
+		listCache:             listCache,
 	}
 
 	// Example client configuration for data sources and resources
 	resp.DataSourceData = clientset
 	resp.ResourceData = clientset
+	resp.EphemeralResourceData = clientset
+}
+
+// stringValueOrEnv returns the configured value, falling back to the named
+// environment variable when the attribute is not set.
+func stringValueOrEnv(value types.String, env string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
 }
 
 func (p *DevzeroProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewClusterResource,
+		NewClusterOptimizationsDisabledResource,
 		NewWorkloadPolicyResource,
 		NewWorkloadPolicyTargetResource,
 		NewNodePolicyResource,
@@ -212,6 +517,12 @@ func (p *DevzeroProvider) Resources(ctx context.Context) []func() resource.Resou
 	}
 }
 
+func (p *DevzeroProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewClusterTokenEphemeralResource,
+	}
+}
+
 func (p *DevzeroProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewClusterIDByNameDataSource,
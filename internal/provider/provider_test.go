@@ -1 +1,271 @@
 package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
+)
+
+// testAccTeamID is the provider team used by acceptance tests.
+const testAccTeamID = "team-acc"
+
+// testAccProtoV6ProviderFactories instantiates the provider for acceptance
+// tests. The provider is pointed at an in-memory fakeapi.Server, so the tests
+// need the Terraform CLI but no Devzero account or network access.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"devzero": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccPreCheck skips acceptance tests when no Terraform CLI is available,
+// rather than letting terraform-plugin-testing download the latest release,
+// which needs network access.
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("Acceptance tests need the Terraform CLI: add terraform to PATH, set TF_ACC_TERRAFORM_PATH to its path, " +
+			"or set TF_ACC_TERRAFORM_VERSION to download that version from releases.hashicorp.com")
+	}
+}
+
+// testAccProviderConfig returns a provider block targeting the fake API server.
+func testAccProviderConfig(server *fakeapi.Server) string {
+	return fmt.Sprintf(`
+provider "devzero" {
+  url      = %q
+  team_id  = %q
+  token    = "test-token"
+  protocol = "connect"
+}
+`, server.URL, testAccTeamID)
+}
+
+// testAccCheckDestroyed verifies that every resource of the given type is gone
+// from the fake API server once the test configuration has been destroyed.
+func testAccCheckDestroyed(resourceType string, exists func(id string) bool) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			if exists(rs.Primary.ID) {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// stubK8SServiceClient overrides selected K8SService RPCs for unit tests.
+// RPCs without an override panic through the nil embedded interface.
+type stubK8SServiceClient struct {
+	apiv1connect.K8SServiceClient
+	getCluster func(context.Context, *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error)
+}
+
+func (c *stubK8SServiceClient) GetCluster(ctx context.Context, req *connect.Request[apiv1.GetClusterRequest]) (*connect.Response[apiv1.GetClusterResponse], error) {
+	return c.getCluster(ctx, req)
+}
+
+// stubRecommendationClient overrides selected K8SRecommendationService RPCs for unit tests.
+// RPCs without an override panic through the nil embedded interface.
+type stubRecommendationClient struct {
+	apiv1connect.K8SRecommendationServiceClient
+	getWorkloadRecommendationPolicy func(context.Context, *connect.Request[apiv1.GetWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.GetWorkloadRecommendationPolicyResponse], error)
+	getWorkloadPolicyTarget         func(context.Context, *connect.Request[apiv1.GetWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.GetWorkloadPolicyTargetResponse], error)
+	listNodePolicies                func(context.Context, *connect.Request[apiv1.ListNodePoliciesRequest]) (*connect.Response[apiv1.ListNodePoliciesResponse], error)
+	listNodePolicyTargets           func(context.Context, *connect.Request[apiv1.ListNodePolicyTargetsRequest]) (*connect.Response[apiv1.ListNodePolicyTargetsResponse], error)
+	getWorkloadRuleByID             func(context.Context, *connect.Request[apiv1.GetWorkloadRuleByIDRequest]) (*connect.Response[apiv1.GetWorkloadRuleByIDResponse], error)
+}
+
+func (c *stubRecommendationClient) GetWorkloadRecommendationPolicy(ctx context.Context, req *connect.Request[apiv1.GetWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.GetWorkloadRecommendationPolicyResponse], error) {
+	return c.getWorkloadRecommendationPolicy(ctx, req)
+}
+
+func (c *stubRecommendationClient) GetWorkloadPolicyTarget(ctx context.Context, req *connect.Request[apiv1.GetWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.GetWorkloadPolicyTargetResponse], error) {
+	return c.getWorkloadPolicyTarget(ctx, req)
+}
+
+func (c *stubRecommendationClient) ListNodePolicies(ctx context.Context, req *connect.Request[apiv1.ListNodePoliciesRequest]) (*connect.Response[apiv1.ListNodePoliciesResponse], error) {
+	return c.listNodePolicies(ctx, req)
+}
+
+func (c *stubRecommendationClient) ListNodePolicyTargets(ctx context.Context, req *connect.Request[apiv1.ListNodePolicyTargetsRequest]) (*connect.Response[apiv1.ListNodePolicyTargetsResponse], error) {
+	return c.listNodePolicyTargets(ctx, req)
+}
+
+func (c *stubRecommendationClient) GetWorkloadRuleByID(ctx context.Context, req *connect.Request[apiv1.GetWorkloadRuleByIDRequest]) (*connect.Response[apiv1.GetWorkloadRuleByIDResponse], error) {
+	return c.getWorkloadRuleByID(ctx, req)
+}
+
+// newTestReadRequest builds a Read request whose prior state only has the id set.
+func newTestReadRequest(t *testing.T, r resource.Resource, id string) (resource.ReadRequest, *resource.ReadResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema had errors: %v", schemaResp.Diagnostics)
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.SetAttribute(ctx, path.Root("id"), id); diags.HasError() {
+		t.Fatalf("Unable to set id in state: %v", diags)
+	}
+
+	req := resource.ReadRequest{State: state}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+	return req, resp
+}
+
+// newTestCreateRequest builds a Create request whose plan only has the given attributes set.
+func newTestCreateRequest(t *testing.T, r resource.Resource, attrs map[string]any) (resource.CreateRequest, *resource.CreateResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema had errors: %v", schemaResp.Diagnostics)
+	}
+
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range attrs {
+		if diags := plan.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("Unable to set %s in plan: %v", name, diags)
+		}
+	}
+
+	req := resource.CreateRequest{Plan: plan}
+	resp := &resource.CreateResponse{State: tfsdk.State{
+		Schema: plan.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	return req, resp
+}
+
+// overrideProcedure returns a client option that answers the given RPC with
+// respond instead of sending it to the server.
+func overrideProcedure(procedure string, respond func() (connect.AnyResponse, error)) connect.ClientOption {
+	return connect.WithInterceptors(connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().Procedure == procedure {
+				return respond()
+			}
+			return next(ctx, req)
+		}
+	}))
+}
+
+// newTestImportStateResponse builds an ImportState response with an empty state.
+func newTestImportStateResponse(t *testing.T, r resource.Resource) *resource.ImportStateResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema had errors: %v", schemaResp.Diagnostics)
+	}
+
+	return &resource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+}
+
+// assertRemovedFromState checks that Read dropped the resource without reporting an error.
+func assertRemovedFromState(t *testing.T, resp *resource.ReadResponse) {
+	t.Helper()
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("Expected resource to be removed from state")
+	}
+}
+
+// assertKeptInState checks that Read reported an error and left the state untouched.
+func assertKeptInState(t *testing.T, resp *resource.ReadResponse) {
+	t.Helper()
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected an error")
+	}
+	if resp.State.Raw.IsNull() {
+		t.Error("Expected resource to remain in state")
+	}
+}
+
+func TestResourcesTeamIDAttribute(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	for _, newResource := range New("test")().Resources(ctx) {
+		r := newResource()
+
+		metadataResp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "devzero"}, metadataResp)
+
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+		attr, ok := schemaResp.Schema.Attributes["team_id"]
+		if !ok {
+			t.Errorf("%s: team_id attribute not found in schema", metadataResp.TypeName)
+			continue
+		}
+		if !attr.IsOptional() || !attr.IsComputed() {
+			t.Errorf("%s: team_id should be optional and computed", metadataResp.TypeName)
+		}
+	}
+}
+
+func TestClientSetTeamID(t *testing.T) {
+	t.Parallel()
+
+	client := &ClientSet{TeamId: "team-provider"}
+
+	tests := map[string]struct {
+		value    types.String
+		expected string
+	}{
+		"null":    {value: types.StringNull(), expected: "team-provider"},
+		"unknown": {value: types.StringUnknown(), expected: "team-provider"},
+		"empty":   {value: types.StringValue(""), expected: "team-provider"},
+		"set":     {value: types.StringValue("team-resource"), expected: "team-resource"},
+	}
+
+	for name, tt := range tests {
+		if got := client.teamID(tt.value); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", name, tt.expected, got)
+		}
+	}
+}
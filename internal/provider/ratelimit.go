@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// errRateLimited marks requests that could not be sent before their deadline
+// because of the client-side limits. Retrying them would only wait again.
+var errRateLimited = errors.New("client-side rate limit would exceed the request deadline")
+
+// requestLimiter throttles the API calls made by every client in a ClientSet.
+// A nil limiter or semaphore disables the corresponding limit.
+type requestLimiter struct {
+	limiter   *rate.Limiter
+	semaphore chan struct{}
+}
+
+// newRequestLimiter returns a limiter allowing requestsPerSecond requests per
+// second, with bursts of up to one second worth of requests, and at most
+// maxConcurrent requests in flight. Zero disables a limit.
+func newRequestLimiter(requestsPerSecond float64, maxConcurrent int) *requestLimiter {
+	l := &requestLimiter{}
+	if requestsPerSecond > 0 {
+		burst := int(math.Max(1, math.Ceil(requestsPerSecond)))
+		l.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+	if maxConcurrent > 0 {
+		l.semaphore = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// acquire blocks until a request may be sent or ctx is done. The returned
+// function must be called once the request has completed. When the rate limit
+// would delay the request past the deadline of ctx, the error wraps
+// errRateLimited; otherwise it is the error of ctx.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+
+	if l.semaphore != nil {
+		select {
+		case l.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		release = func() { <-l.semaphore }
+	}
+
+	if l.limiter != nil {
+		if err := l.limiter.Wait(ctx); err != nil {
+			release()
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, fmt.Errorf("%w: %w", errRateLimited, err)
+		}
+	}
+
+	return release, nil
+}
+
+// interceptor applies the limits to every RPC, logging how long each call
+// was throttled.
+func (l *requestLimiter) interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			start := time.Now()
+			release, err := l.acquire(ctx)
+			if err != nil {
+				switch {
+				case errors.Is(err, context.Canceled):
+					return nil, connect.NewError(connect.CodeCanceled, err)
+				case errors.Is(err, errRateLimited):
+					return nil, connect.NewError(connect.CodeResourceExhausted, err)
+				default:
+					return nil, connect.NewError(connect.CodeDeadlineExceeded, err)
+				}
+			}
+			defer release()
+
+			if waited := time.Since(start); waited >= time.Millisecond {
+				tflog.Debug(ctx, "Devzero API request throttled by client-side rate limit", map[string]any{
+					"procedure": req.Spec().Procedure,
+					"waited":    waited.String(),
+				})
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
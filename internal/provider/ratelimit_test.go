@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
+)
+
+func TestRequestLimiter_Rate(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(50, 0)
+
+	// The first 50 requests use the burst, the next 10 need another 200ms.
+	start := time.Now()
+	for i := 0; i < 60; i++ {
+		release, err := limiter.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		release()
+	}
+
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Expected requests to be throttled, took %s", elapsed)
+	}
+}
+
+func TestRequestLimiter_Concurrency(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(0, 2)
+
+	releaseFirst, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the third request to wait until the context expired, got %v", err)
+	}
+
+	releaseFirst()
+	if _, err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("Expected a slot to be free after release, got %v", err)
+	}
+}
+
+func TestRequestLimiter_ContextCancel(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(0.1, 1)
+
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	release()
+
+	// The next token is 10 seconds away, longer than the context allows.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	// A failed rate limit wait must give the concurrency slot back.
+	select {
+	case limiter.semaphore <- struct{}{}:
+	default:
+		t.Error("Expected the concurrency slot to be released")
+	}
+}
+
+func TestRequestLimiter_Interceptor(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight atomic.Int32
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewK8SServiceHandler(&flakyK8SServiceHandler{}))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	limiter := newRequestLimiter(0, 3)
+	client := apiv1connect.NewK8SServiceClient(server.Client(), server.URL, connect.WithInterceptors(limiter.interceptor()))
+
+	var output bytes.Buffer
+	var outputMu sync.Mutex
+	ctx := tflogtest.RootLogger(context.Background(), &lockedWriter{mu: &outputMu, w: &output})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetCluster(ctx, connect.NewRequest(&apiv1.GetClusterRequest{ClusterId: "cluster-1"})); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got > 3 {
+		t.Errorf("Expected at most 3 requests in flight, got %d", got)
+	}
+	if !strings.Contains(output.String(), "throttled by client-side rate limit") {
+		t.Error("Expected throttled requests to be logged")
+	}
+}
+
+func TestRequestLimiter_InterceptorContextCancel(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(0, 1)
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer release()
+
+	// The request never reaches the network, so the URL is never dialed.
+	client := apiv1connect.NewK8SServiceClient(http.DefaultClient, "http://127.0.0.1:0", connect.WithInterceptors(limiter.interceptor()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.GetCluster(ctx, connect.NewRequest(&apiv1.GetClusterRequest{ClusterId: "cluster-1"}))
+	if connect.CodeOf(err) != connect.CodeCanceled {
+		t.Errorf("Expected canceled error, got %v", err)
+	}
+}
+
+func TestRequestLimiter_InterceptorDeadline(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(0.1, 0)
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	release()
+
+	// The next token is ten seconds away, past the deadline, so the request never reaches the network.
+	client := apiv1connect.NewK8SServiceClient(http.DefaultClient, "http://127.0.0.1:0", connect.WithInterceptors(limiter.interceptor()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err = client.GetCluster(ctx, connect.NewRequest(&apiv1.GetClusterRequest{ClusterId: "cluster-1"}))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("Expected resource exhausted error, got %v", err)
+	}
+	if isRetryableError(err) {
+		t.Errorf("Expected rate limited error not to be retried, got %v", err)
+	}
+}
+
+func TestRequestLimiter_InterceptorConcurrencyDeadline(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(0, 1)
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer release()
+
+	// The only slot is held, so the request waits until the deadline and never reaches the network.
+	client := apiv1connect.NewK8SServiceClient(http.DefaultClient, "http://127.0.0.1:0", connect.WithInterceptors(limiter.interceptor()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.GetCluster(ctx, connect.NewRequest(&apiv1.GetClusterRequest{ClusterId: "cluster-1"}))
+	if connect.CodeOf(err) != connect.CodeDeadlineExceeded {
+		t.Errorf("Expected deadline exceeded error, got %v", err)
+	}
+	if errors.Is(err, errRateLimited) {
+		t.Errorf("Expected the concurrency limit not to be reported as rate limited, got %v", err)
+	}
+}
+
+// lockedWriter serializes writes from concurrent loggers.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  *bytes.Buffer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// Default operation timeouts, used when a resource has no timeouts block.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// timeoutsBlock returns the schema of the timeouts block shared by all resources.
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: fmt.Sprintf("Time allowed to create the resource, as a duration string (e.g. `30s`, `10m`). Defaults to `%s`.", formatDuration(defaultCreateTimeout)),
+		ReadDescription:   fmt.Sprintf("Time allowed to read the resource during refresh, as a duration string (e.g. `30s`, `10m`). Defaults to `%s`.", formatDuration(defaultReadTimeout)),
+		UpdateDescription: fmt.Sprintf("Time allowed to update the resource, as a duration string (e.g. `30s`, `10m`). Defaults to `%s`.", formatDuration(defaultUpdateTimeout)),
+		DeleteDescription: fmt.Sprintf("Time allowed to delete the resource, as a duration string (e.g. `30s`, `10m`). Defaults to `%s`.", formatDuration(defaultDeleteTimeout)),
+	})
+}
+
+// withTimeout bounds ctx by the timeout of a resource operation ("create",
+// "read", "update" or "delete"). The returned function must be deferred: it
+// releases the context and, if the operation failed because the deadline was
+// hit, adds a diagnostic explaining which timeout to raise. An operation that
+// completed without errors is not reported, even if the deadline passed while
+// it finished, as an error would taint a resource that was just created.
+func withTimeout(ctx context.Context, diags *diag.Diagnostics, operation string, timeout time.Duration) (context.Context, func()) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	return ctx, func() {
+		if diags.HasError() && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			diags.AddError(
+				"Operation Timed Out",
+				fmt.Sprintf("The %s operation did not complete within %s. "+
+					"Increase timeouts.%s in the resource configuration if the Devzero API needs more time.", operation, formatDuration(timeout), operation),
+			)
+		}
+		cancel()
+	}
+}
+
+// formatDuration formats d without the zero units time.Duration.String adds, e.g. "20m" rather than "20m0s".
+func formatDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
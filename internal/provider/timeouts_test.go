@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestResourcesTimeoutsBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	for _, newResource := range New("test")().Resources(ctx) {
+		r := newResource()
+
+		metadataResp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "devzero"}, metadataResp)
+
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+		if _, ok := schemaResp.Schema.Blocks["timeouts"]; !ok {
+			t.Errorf("%s: timeouts block not found in schema", metadataResp.TypeName)
+		}
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+	ctx, done := withTimeout(context.Background(), &diags, "create", 10*time.Millisecond)
+	<-ctx.Done()
+	diags.AddError("Client Error", fmt.Sprintf("Unable to create cluster, got error: %s", ctx.Err()))
+	done()
+
+	if len(diags) != 2 {
+		t.Fatalf("Expected a timeout error, got %v", diags)
+	}
+	if detail := diags[1].Detail(); !strings.Contains(detail, "timeouts.create") {
+		t.Errorf("Expected detail to mention timeouts.create, got %q", detail)
+	}
+}
+
+func TestWithTimeout_CompletedAfterDeadline(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+	ctx, done := withTimeout(context.Background(), &diags, "create", 10*time.Millisecond)
+	<-ctx.Done()
+	diags.AddWarning("Cluster Tags Not Applied", "The next plan shows the missing tags.")
+	done()
+
+	if diags.HasError() {
+		t.Errorf("Expected no error, got %v", diags)
+	}
+}
+
+func TestWithTimeout_Completed(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+	_, done := withTimeout(context.Background(), &diags, "read", time.Minute)
+	done()
+
+	if diags.HasError() {
+		t.Errorf("Expected no error, got %v", diags)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := map[time.Duration]string{
+		2 * time.Hour:           "2h",
+		20 * time.Minute:        "20m",
+		90 * time.Second:        "1m30s",
+		1500 * time.Millisecond: "1.5s",
+	}
+
+	for d, expected := range tests {
+		if got := formatDuration(d); got != expected {
+			t.Errorf("%s: expected %q, got %q", d, expected, got)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+const (
+	protocolGRPC    = "grpc"
+	protocolGRPCWeb = "grpcweb"
+	protocolConnect = "connect"
+
+	codecProto = "proto"
+	codecJSON  = "json"
+)
+
+var (
+	supportedProtocols = []string{protocolGRPC, protocolGRPCWeb, protocolConnect}
+	supportedCodecs    = []string{codecProto, codecJSON}
+)
+
+// transportConfig holds the HTTP transport settings shared by every API client.
+type transportConfig struct {
+	caCertPEM          string
+	caCertFile         string
+	clientCert         string
+	clientKey          string
+	insecureSkipVerify bool
+	proxyURL           string
+	requestTimeout     time.Duration
+}
+
+// newHTTPClient builds the http.Client used by all Devzero API clients.
+// Unset settings fall back to the behaviour of http.DefaultTransport.
+func newHTTPClient(cfg transportConfig) (*http.Client, error) {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.insecureSkipVerify,
+	}
+
+	if cfg.caCertPEM != "" || cfg.caCertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if cfg.caCertPEM != "" && !pool.AppendCertsFromPEM([]byte(cfg.caCertPEM)) {
+			return nil, errors.New("ca_cert_pem does not contain any valid PEM-encoded certificates")
+		}
+
+		if cfg.caCertFile != "" {
+			pem, err := os.ReadFile(cfg.caCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read ca_cert_file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("ca_cert_file %q does not contain any valid PEM-encoded certificates", cfg.caCertFile)
+			}
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.clientCert != "" || cfg.clientKey != "" {
+		if cfg.clientCert == "" || cfg.clientKey == "" {
+			return nil, errors.New("client_cert and client_key must be set together")
+		}
+
+		certPEM, err := readPEM(cfg.clientCert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client_cert: %w", err)
+		}
+		keyPEM, err := readPEM(cfg.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client_key: %w", err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if cfg.proxyURL != "" {
+		proxy, err := url.Parse(cfg.proxyURL)
+		if err != nil || proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf("proxy_url %q is not a valid URL", cfg.proxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   cfg.requestTimeout,
+	}, nil
+}
+
+// readPEM returns value as-is when it is PEM-encoded, otherwise it treats
+// value as the path to a PEM file.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// protocolOptions returns the client options selecting the wire protocol and
+// codec. Empty values select gRPC with the binary protobuf codec.
+func protocolOptions(protocol, codec string) ([]connect.ClientOption, error) {
+	var opts []connect.ClientOption
+
+	switch protocol {
+	case "", protocolGRPC:
+		opts = append(opts, connect.WithGRPC())
+	case protocolGRPCWeb:
+		opts = append(opts, connect.WithGRPCWeb())
+	case protocolConnect:
+		// The Connect protocol is the connect-go default.
+	default:
+		return nil, fmt.Errorf("protocol %q is not supported, must be one of: %s", protocol, strings.Join(supportedProtocols, ", "))
+	}
+
+	switch codec {
+	case "", codecProto:
+	case codecJSON:
+		opts = append(opts, connect.WithProtoJSON())
+	default:
+		return nil, fmt.Errorf("codec %q is not supported, must be one of: %s", codec, strings.Join(supportedCodecs, ", "))
+	}
+
+	return opts, nil
+}
@@ -3,9 +3,23 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 func getElementList[T any, V any](ctx context.Context, values []attr.Value, converter func(ctx context.Context, value V) (T, error)) ([]T, error) {
@@ -13,15 +27,25 @@ func getElementList[T any, V any](ctx context.Context, values []attr.Value, conv
 	var errs []error
 	for _, value := range values {
 		var v V
-		value, err := value.ToTerraformValue(ctx)
-		if err != nil {
-			errs = append(errs, err)
-			continue
-		}
-		err = value.As(&v)
-		if err != nil {
-			errs = append(errs, err)
-			continue
+		if _, ok := value.(basetypes.ObjectValuable); ok {
+			// tftypes cannot unmarshal objects into structs, so let the framework map them through their tfsdk tags.
+			if diags := tfsdk.ValueAs(ctx, value, &v); diags.HasError() {
+				for _, d := range diags.Errors() {
+					errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+				}
+				continue
+			}
+		} else {
+			value, err := value.ToTerraformValue(ctx)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			err = value.As(&v)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
 		}
 
 		element, err := converter(ctx, v)
@@ -49,15 +73,25 @@ func getElementMap[K comparable, V any, T any](ctx context.Context, values map[K
 	var errs []error
 	for key, value := range values {
 		var v V
-		value, err := value.ToTerraformValue(ctx)
-		if err != nil {
-			errs = append(errs, err)
-			continue
-		}
-		err = value.As(&v)
-		if err != nil {
-			errs = append(errs, err)
-			continue
+		if _, ok := value.(basetypes.ObjectValuable); ok {
+			// tftypes cannot unmarshal objects into structs, so let the framework map them through their tfsdk tags.
+			if diags := tfsdk.ValueAs(ctx, value, &v); diags.HasError() {
+				for _, d := range diags.Errors() {
+					errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+				}
+				continue
+			}
+		} else {
+			value, err := value.ToTerraformValue(ctx)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			err = value.As(&v)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
 		}
 		element, err := converter(ctx, v)
 		if err != nil {
@@ -95,6 +129,21 @@ func fromStringList(values []string) []attr.Value {
 	return stringList
 }
 
+// isNotFound reports whether err is a connect error signalling that the
+// requested object does not exist in the backend.
+func isNotFound(err error) bool {
+	return connect.CodeOf(err) == connect.CodeNotFound
+}
+
+// removeMissingResource drops a resource that no longer exists in the backend
+// from state, so that the next plan re-creates it instead of failing.
+func removeMissingResource(ctx context.Context, state *tfsdk.State, kind string, id string) {
+	tflog.Warn(ctx, fmt.Sprintf("%s not found in the backend, removing it from state.", kind), map[string]any{
+		"id": id,
+	})
+	state.RemoveResource(ctx)
+}
+
 func fromStringMap(values map[string]string) map[string]attr.Value {
 	stringMap := make(map[string]attr.Value)
 	for key, value := range values {
@@ -102,3 +151,133 @@ func fromStringMap(values map[string]string) map[string]attr.Value {
 	}
 	return stringMap
 }
+
+// teamIDAttribute returns the schema of the optional per-resource team_id,
+// which defaults to the provider team_id.
+func teamIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "ID of the Devzero team that owns the resource. Defaults to the provider `team_id`. Changing it forces a new resource.",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// importStateWithTeamID imports a resource from either "<resource_id>" or
+// "<team_id>/<resource_id>".
+func importStateWithTeamID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[0])...)
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <resource_id> or <team_id>/<resource_id>. Got: %q", req.ID),
+		)
+	}
+}
+
+// importNamePrefix marks an import identifier that references a resource by
+// name rather than by ID.
+const importNamePrefix = "name:"
+
+// importStateWithName imports a resource like importStateWithTeamID, but also
+// accepts "name:<name>" or "<team_id>/name:<name>". The name is resolved to
+// an ID of the team through lookup, which must fail if the name matches no
+// resource or more than one.
+func importStateWithName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, kind string, teamID func(types.String) string, lookup func(ctx context.Context, teamID string, name string) (string, error)) {
+	team, name := "", ""
+	if after, ok := strings.CutPrefix(req.ID, importNamePrefix); ok {
+		name = after
+	} else if before, after, ok := strings.Cut(req.ID, "/"); ok && strings.HasPrefix(after, importNamePrefix) {
+		team, name = before, strings.TrimPrefix(after, importNamePrefix)
+		if team == "" {
+			name = ""
+		}
+	} else {
+		importStateWithTeamID(ctx, req, resp)
+		return
+	}
+
+	if name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: name:<name> or <team_id>/name:<name>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	teamValue := types.StringNull()
+	if team != "" {
+		teamValue = types.StringValue(team)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), team)...)
+	}
+
+	id, err := lookup(ctx, teamID(teamValue), name)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to import %s %q by name, got error: %s", kind, name, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// matchName returns the only ID in ids, the IDs of the resources of a team
+// named name, or an error if there is none or more than one.
+func matchName(kind string, teamID string, name string, ids []string) (string, error) {
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s named %q found in team %q", kind, name, teamID)
+	case 1:
+		return ids[0], nil
+	default:
+		slices.Sort(ids)
+		return "", fmt.Errorf("more than one %s is named %q in team %q (IDs %s), import it by ID instead", kind, name, teamID, strings.Join(ids, ", "))
+	}
+}
+
+// durationValidator checks that a string is a positive duration, such as "30s" or "10m".
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return `value must be a positive duration string, such as "30s" or "10m"`
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("The value %q is not a valid positive duration (e.g. \"30s\", \"10m\").", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// durationValue returns the duration of a string validated by durationValidator, or def if it is not set.
+func durationValue(value types.String, def time.Duration) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return def
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return def
+	}
+	return d
+}
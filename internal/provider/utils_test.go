@@ -2,10 +2,18 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
+	"connectrpc.com/connect"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestGetStringList(t *testing.T) {
@@ -204,3 +212,150 @@ func TestFromElementList(t *testing.T) {
 		}
 	}
 }
+
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	notFound := connect.NewError(connect.CodeNotFound, errors.New("policy not found"))
+
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"nil":               {err: nil, expected: false},
+		"not found":         {err: notFound, expected: true},
+		"wrapped not found": {err: fmt.Errorf("get policy: %w", notFound), expected: true},
+		"permission denied": {err: connect.NewError(connect.CodePermissionDenied, errors.New("denied")), expected: false},
+		"plain error":       {err: errors.New("not found"), expected: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isNotFound(tt.err); got != tt.expected {
+				t.Errorf("Expected isNotFound=%t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestImportStateWithTeamID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		importID       string
+		expectedID     string
+		expectedTeamID string
+		expectError    bool
+	}{
+		"resource id":          {importID: "cluster-1", expectedID: "cluster-1"},
+		"team and resource id": {importID: "team-1/cluster-1", expectedID: "cluster-1", expectedTeamID: "team-1"},
+		"empty":                {importID: "", expectError: true},
+		"empty team id":        {importID: "/cluster-1", expectError: true},
+		"too many parts":       {importID: "team-1/cluster-1/extra", expectError: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			schemaResp := &resource.SchemaResponse{}
+			NewClusterResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			resp := &resource.ImportStateResponse{State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}}
+			importStateWithTeamID(ctx, resource.ImportStateRequest{ID: tt.importID}, resp)
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+
+			var id, teamID types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			resp.State.GetAttribute(ctx, path.Root("team_id"), &teamID)
+			if id.ValueString() != tt.expectedID {
+				t.Errorf("Expected id %q, got %q", tt.expectedID, id.ValueString())
+			}
+			if teamID.ValueString() != tt.expectedTeamID {
+				t.Errorf("Expected team_id %q, got %q", tt.expectedTeamID, teamID.ValueString())
+			}
+		})
+	}
+}
+
+func TestImportStateWithName(t *testing.T) {
+	t.Parallel()
+
+	ids := map[string][]string{
+		"team-1/cluster": {"cluster-1"},
+		"team-2/cluster": {"cluster-2"},
+		"team-1/twins":   {"cluster-3", "cluster-4"},
+	}
+	lookup := func(ctx context.Context, teamID string, name string) (string, error) {
+		return matchName("cluster", teamID, name, ids[teamID+"/"+name])
+	}
+	teamID := func(value types.String) string {
+		if value.ValueString() != "" {
+			return value.ValueString()
+		}
+		return "team-1"
+	}
+
+	tests := map[string]struct {
+		importID       string
+		expectedID     string
+		expectedTeamID string
+		expectError    string
+	}{
+		"resource id":          {importID: "cluster-1", expectedID: "cluster-1"},
+		"team and resource id": {importID: "team-2/cluster-2", expectedID: "cluster-2", expectedTeamID: "team-2"},
+		"name":                 {importID: "name:cluster", expectedID: "cluster-1"},
+		"team and name":        {importID: "team-2/name:cluster", expectedID: "cluster-2", expectedTeamID: "team-2"},
+		"name with slash":      {importID: "name:a/b", expectError: `no cluster named "a/b" found in team "team-1"`},
+		"unknown name":         {importID: "name:missing", expectError: `no cluster named "missing" found in team "team-1"`},
+		"ambiguous name":       {importID: "name:twins", expectError: "more than one cluster is named \"twins\" in team \"team-1\" (IDs cluster-3, cluster-4)"},
+		"empty name":           {importID: "name:", expectError: "Expected import identifier"},
+		"empty team id":        {importID: "/name:cluster", expectError: "Expected import identifier"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			resp := newTestImportStateResponse(t, NewClusterResource())
+			importStateWithName(ctx, resource.ImportStateRequest{ID: tt.importID}, resp, "cluster", teamID, lookup)
+
+			if tt.expectError != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected an error")
+				}
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.expectError) {
+					t.Errorf("Expected error detail to contain %q, got %q", tt.expectError, detail)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+
+			var id, teamID types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			resp.State.GetAttribute(ctx, path.Root("team_id"), &teamID)
+			if id.ValueString() != tt.expectedID {
+				t.Errorf("Expected id %q, got %q", tt.expectedID, id.ValueString())
+			}
+			if teamID.ValueString() != tt.expectedTeamID {
+				t.Errorf("Expected team_id %q, got %q", tt.expectedTeamID, teamID.ValueString())
+			}
+		})
+	}
+}
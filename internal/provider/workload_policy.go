@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -30,6 +31,7 @@ import (
 var _ resource.Resource = &WorkloadPolicyResource{}
 var _ resource.ResourceWithConfigure = &WorkloadPolicyResource{}
 var _ resource.ResourceWithImportState = &WorkloadPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &WorkloadPolicyResource{}
 
 func NewWorkloadPolicyResource() resource.Resource {
 	return &WorkloadPolicyResource{}
@@ -43,6 +45,7 @@ type WorkloadPolicyResource struct {
 // ExampleResourceModel describes the resource data model.
 type WorkloadPolicyResourceModel struct {
 	Id                      types.String              `tfsdk:"id"`
+	TeamId                  types.String              `tfsdk:"team_id"`
 	Name                    types.String              `tfsdk:"name"`
 	Description             types.String              `tfsdk:"description"`
 	ActionTriggers          types.List                `tfsdk:"action_triggers"`
@@ -67,6 +70,9 @@ type WorkloadPolicyResourceModel struct {
 	CooldownMinutes         types.Int32               `tfsdk:"cooldown_minutes"`
 	EnablePmaxProtection    types.Bool                `tfsdk:"enable_pmax_protection"`
 	PmaxRatioThreshold      types.Float32             `tfsdk:"pmax_ratio_threshold"`
+	DeletionProtection      types.Bool                `tfsdk:"deletion_protection"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type VerticalScalingOptions struct {
@@ -190,6 +196,7 @@ func (r *WorkloadPolicyResource) Schema(ctx context.Context, req resource.Schema
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"team_id": teamIDAttribute(),
 			"name": schema.StringAttribute{
 				Description:         "Human-friendly name for the policy",
 				MarkdownDescription: "Human-friendly name for the policy. Used for display in the DevZero UI.",
@@ -415,6 +422,11 @@ func (r *WorkloadPolicyResource) Schema(ctx context.Context, req resource.Schema
 				Computed:            true,
 				Default:             float32default.StaticFloat32(3.0),
 			},
+			"deletion_protection": deletionProtectionAttribute("workload policy"),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
@@ -439,6 +451,10 @@ func (r *WorkloadPolicyResource) Configure(ctx context.Context, req resource.Con
 	r.client = client
 }
 
+func (r *WorkloadPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkDeletionProtection(ctx, req, resp, "workload policy")
+}
+
 func (r *WorkloadPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data WorkloadPolicyResourceModel
 
@@ -448,10 +464,22 @@ func (r *WorkloadPolicyResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	policy := data.toProto(ctx, &resp.Diagnostics, r.client.TeamId)
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "create", createTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
+	policy := data.toProto(ctx, &resp.Diagnostics, teamId)
 
 	createWorkloadPolicyReq := &apiv1.CreateWorkloadRecommendationPolicyRequest{
-		TeamId: r.client.TeamId,
+		TeamId: teamId,
 		Policy: policy,
 	}
 
@@ -484,19 +512,35 @@ func (r *WorkloadPolicyResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "read", readTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	getWorkloadPolicyReq := &apiv1.GetWorkloadRecommendationPolicyRequest{
-		TeamId:   r.client.TeamId,
+		TeamId:   teamId,
 		PolicyId: data.Id.ValueString(),
 	}
 
 	getWorkloadPolicyResp, err := r.client.RecommendationClient.GetWorkloadRecommendationPolicy(ctx, connect.NewRequest(getWorkloadPolicyReq))
 	if err != nil {
+		if isNotFound(err) {
+			removeMissingResource(ctx, &resp.State, "Workload policy", data.Id.ValueString())
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get workload policy, got error: %s", err))
 		return
 	}
 
 	if getWorkloadPolicyResp.Msg.Policy == nil {
-		resp.Diagnostics.AddError("Client Error", "Workload policy not found")
+		removeMissingResource(ctx, &resp.State, "Workload policy", data.Id.ValueString())
 		return
 	}
 
@@ -516,9 +560,21 @@ func (r *WorkloadPolicyResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "update", updateTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	updateWorkloadPolicyReq := &apiv1.UpdateWorkloadRecommendationPolicyRequest{
-		TeamId: r.client.TeamId,
-		Policy: data.toProto(ctx, &resp.Diagnostics, r.client.TeamId),
+		TeamId: teamId,
+		Policy: data.toProto(ctx, &resp.Diagnostics, teamId),
 	}
 
 	updateWorkloadPolicyResp, err := r.client.RecommendationClient.UpdateWorkloadRecommendationPolicy(ctx, connect.NewRequest(updateWorkloadPolicyReq))
@@ -548,8 +604,24 @@ func (r *WorkloadPolicyResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	if data.DeletionProtection.ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, "workload policy", data.Id.ValueString())
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "delete", deleteTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+
 	deleteWorkloadPolicyReq := &apiv1.DeleteWorkloadRecommendationPolicyRequest{
-		TeamId:   r.client.TeamId,
+		TeamId:   teamId,
 		PolicyId: data.Id.ValueString(),
 	}
 
@@ -561,7 +633,28 @@ func (r *WorkloadPolicyResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *WorkloadPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithName(ctx, req, resp, "workload policy", r.client.teamID, r.lookupIDByName)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+}
+
+// lookupIDByName resolves the name of a workload policy to its ID.
+func (r *WorkloadPolicyResource) lookupIDByName(ctx context.Context, teamId string, name string) (string, error) {
+	listWorkloadPoliciesReq := &apiv1.ListWorkloadRecommendationPoliciesRequest{
+		TeamId: teamId,
+	}
+
+	listWorkloadPoliciesResp, err := r.client.RecommendationClient.ListWorkloadRecommendationPolicies(ctx, connect.NewRequest(listWorkloadPoliciesReq))
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	for _, policy := range listWorkloadPoliciesResp.Msg.Policies {
+		if policy.Name == name {
+			ids = append(ids, policy.PolicyId)
+		}
+	}
+	return matchName("workload policy", teamId, name, ids)
 }
 
 func (m *WorkloadPolicyResourceModel) toProto(ctx context.Context, diags *diag.Diagnostics, teamId string) *apiv1.WorkloadRecommendationPolicy {
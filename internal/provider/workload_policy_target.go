@@ -8,8 +8,8 @@ import (
 	"fmt"
 
 	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -41,6 +41,7 @@ type WorkloadPolicyTargetResource struct {
 // ExampleResourceModel describes the resource data model.
 type WorkloadPolicyTargetResourceModel struct {
 	Id                types.String   `tfsdk:"id"`
+	TeamId            types.String   `tfsdk:"team_id"`
 	PolicyId          types.String   `tfsdk:"policy_id"`
 	Name              types.String   `tfsdk:"name"`
 	Description       types.String   `tfsdk:"description"`
@@ -54,6 +55,8 @@ type WorkloadPolicyTargetResourceModel struct {
 	WorkloadNames     types.List     `tfsdk:"workload_names"`
 	NodeGroupNames    types.List     `tfsdk:"node_group_names"`
 	ClusterIds        types.List     `tfsdk:"cluster_ids"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type LabelSelector struct {
@@ -145,6 +148,7 @@ func (r *WorkloadPolicyTargetResource) Schema(ctx context.Context, req resource.
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"team_id": teamIDAttribute(),
 			"policy_id": schema.StringAttribute{
 				Description:         "Workload policy to attach this target to",
 				MarkdownDescription: "Workload policy to attach this target to. Must reference an existing `devzero_workload_policy` resource ID.",
@@ -231,6 +235,10 @@ func (r *WorkloadPolicyTargetResource) Schema(ctx context.Context, req resource.
 				ElementType:         types.StringType,
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -263,6 +271,18 @@ func (r *WorkloadPolicyTargetResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "create", createTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	kindFilters, err := getKindFilters(ctx, data.KindFilter.Elements())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to convert kind filter to Terraform value, got error: %s", err))
@@ -299,7 +319,7 @@ func (r *WorkloadPolicyTargetResource) Create(ctx context.Context, req resource.
 	}
 
 	createWorkloadPolicyTargetReq := &apiv1.CreateWorkloadPolicyTargetRequest{
-		TeamId:            r.client.TeamId,
+		TeamId:            teamId,
 		PolicyId:          data.PolicyId.ValueString(),
 		Name:              data.Name.ValueString(),
 		Description:       data.Description.ValueString(),
@@ -345,19 +365,35 @@ func (r *WorkloadPolicyTargetResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "read", readTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	getWorkloadPolicyTargetReq := &apiv1.GetWorkloadPolicyTargetRequest{
-		TeamId:   r.client.TeamId,
+		TeamId:   teamId,
 		TargetId: data.Id.ValueString(),
 	}
 
 	getWorkloadPolicyTargetResp, err := r.client.RecommendationClient.GetWorkloadPolicyTarget(ctx, connect.NewRequest(getWorkloadPolicyTargetReq))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get cluster, got error: %s", err))
+		if isNotFound(err) {
+			removeMissingResource(ctx, &resp.State, "Workload policy target", data.Id.ValueString())
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get workload policy target, got error: %s", err))
 		return
 	}
 
 	if getWorkloadPolicyTargetResp.Msg.Target == nil {
-		resp.Diagnostics.AddError("Client Error", "Workload policy target not found")
+		removeMissingResource(ctx, &resp.State, "Workload policy target", data.Id.ValueString())
 		return
 	}
 
@@ -377,6 +413,18 @@ func (r *WorkloadPolicyTargetResource) Update(ctx context.Context, req resource.
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "update", updateTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	kindFilters, err := getKindFilters(ctx, data.KindFilter.Elements())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to convert kind filter to Terraform value, got error: %s", err))
@@ -413,7 +461,7 @@ func (r *WorkloadPolicyTargetResource) Update(ctx context.Context, req resource.
 	}
 
 	updateWorkloadPolicyTargetReq := &apiv1.UpdateWorkloadPolicyTargetRequest{
-		TeamId:            r.client.TeamId,
+		TeamId:            teamId,
 		TargetId:          data.Id.ValueString(),
 		PolicyId:          data.PolicyId.ValueStringPointer(),
 		Name:              data.Name.ValueString(),
@@ -457,8 +505,19 @@ func (r *WorkloadPolicyTargetResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "delete", deleteTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+
 	deleteWorkloadPolicyTargetReq := &apiv1.DeleteWorkloadPolicyTargetRequest{
-		TeamId:    r.client.TeamId,
+		TeamId:    teamId,
 		TargetIds: []string{data.Id.ValueString()},
 	}
 
@@ -470,7 +529,7 @@ func (r *WorkloadPolicyTargetResource) Delete(ctx context.Context, req resource.
 }
 
 func (r *WorkloadPolicyTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithTeamID(ctx, req, resp)
 }
 
 func (l *LabelSelector) toProto(ctx context.Context) (*apiv1.LabelSelector, error) {
@@ -2,13 +2,18 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
+	"connectrpc.com/connect"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
 	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
 )
 
@@ -504,3 +509,118 @@ func validateTargetSchema(t *testing.T, schema schema.Schema) {
 		t.Error("namespace_pattern attribute not found")
 	}
 }
+
+func TestWorkloadPolicyTargetResourceRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		getWorkloadPolicyTarget func(context.Context, *connect.Request[apiv1.GetWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.GetWorkloadPolicyTargetResponse], error)
+		removed                 bool
+	}{
+		"not found error": {
+			getWorkloadPolicyTarget: func(context.Context, *connect.Request[apiv1.GetWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.GetWorkloadPolicyTargetResponse], error) {
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("target not found"))
+			},
+			removed: true,
+		},
+		"nil target": {
+			getWorkloadPolicyTarget: func(context.Context, *connect.Request[apiv1.GetWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.GetWorkloadPolicyTargetResponse], error) {
+				return connect.NewResponse(&apiv1.GetWorkloadPolicyTargetResponse{}), nil
+			},
+			removed: true,
+		},
+		"unavailable": {
+			getWorkloadPolicyTarget: func(context.Context, *connect.Request[apiv1.GetWorkloadPolicyTargetRequest]) (*connect.Response[apiv1.GetWorkloadPolicyTargetResponse], error) {
+				return nil, connect.NewError(connect.CodeUnavailable, errors.New("service unavailable"))
+			},
+			removed: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &WorkloadPolicyTargetResource{client: &ClientSet{
+				TeamId:               "team-1",
+				RecommendationClient: &stubRecommendationClient{getWorkloadPolicyTarget: tt.getWorkloadPolicyTarget},
+			}}
+			req, resp := newTestReadRequest(t, r, "target-1")
+			r.Read(context.Background(), req, resp)
+			if tt.removed {
+				assertRemovedFromState(t, resp)
+			} else {
+				assertKeptInState(t, resp)
+			}
+		})
+	}
+}
+
+func TestAccWorkloadPolicyTargetResource(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_workload_policy_target", func(id string) bool {
+			return server.WorkloadPolicyTarget(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWorkloadPolicyTargetResourceConfig(server, "acc-target", "Deployment"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("devzero_workload_policy_target.test", "id"),
+					tfresource.TestCheckResourceAttrPair("devzero_workload_policy_target.test", "policy_id", "devzero_workload_policy.test", "id"),
+					tfresource.TestCheckResourceAttrPair("devzero_workload_policy_target.test", "cluster_ids.0", "devzero_cluster.test", "id"),
+					tfresource.TestCheckResourceAttr("devzero_workload_policy_target.test", "name", "acc-target"),
+					tfresource.TestCheckResourceAttr("devzero_workload_policy_target.test", "kind_filter.0", "Deployment"),
+					tfresource.TestCheckResourceAttr("devzero_workload_policy_target.test", "name_pattern.pattern", "^api-"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "devzero_workload_policy_target.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccWorkloadPolicyTargetResourceConfig(server, "acc-target-updated", "StatefulSet"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_workload_policy_target.test", "name", "acc-target-updated"),
+					tfresource.TestCheckResourceAttr("devzero_workload_policy_target.test", "kind_filter.0", "StatefulSet"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccWorkloadPolicyTargetResourceConfig(server *fakeapi.Server, name string, kind string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "devzero_cluster" "test" {
+  name = "acc-cluster"
+}
+
+resource "devzero_workload_policy" "test" {
+  name = "acc-policy"
+}
+
+resource "devzero_workload_policy_target" "test" {
+  name        = %q
+  policy_id   = devzero_workload_policy.test.id
+  cluster_ids = [devzero_cluster.test.id]
+  kind_filter = [%q]
+
+  name_pattern = {
+    pattern = "^api-"
+    flags   = "i"
+  }
+
+  namespace_selector = {
+    match_labels = {
+      team = "platform"
+    }
+  }
+}
+`, name, kind)
+}
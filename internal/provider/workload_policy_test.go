@@ -2,15 +2,23 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"connectrpc.com/connect"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
 	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
 )
 
 func TestWorkloadPolicyResourceSchema(t *testing.T) {
@@ -302,3 +310,170 @@ func validateSchema(t *testing.T, s schema.Schema) {
 		}
 	}
 }
+
+func TestWorkloadPolicyResourceRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		getWorkloadRecommendationPolicy func(context.Context, *connect.Request[apiv1.GetWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.GetWorkloadRecommendationPolicyResponse], error)
+		removed                         bool
+	}{
+		"not found error": {
+			getWorkloadRecommendationPolicy: func(context.Context, *connect.Request[apiv1.GetWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.GetWorkloadRecommendationPolicyResponse], error) {
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("policy not found"))
+			},
+			removed: true,
+		},
+		"nil policy": {
+			getWorkloadRecommendationPolicy: func(context.Context, *connect.Request[apiv1.GetWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.GetWorkloadRecommendationPolicyResponse], error) {
+				return connect.NewResponse(&apiv1.GetWorkloadRecommendationPolicyResponse{}), nil
+			},
+			removed: true,
+		},
+		"unavailable": {
+			getWorkloadRecommendationPolicy: func(context.Context, *connect.Request[apiv1.GetWorkloadRecommendationPolicyRequest]) (*connect.Response[apiv1.GetWorkloadRecommendationPolicyResponse], error) {
+				return nil, connect.NewError(connect.CodeUnavailable, errors.New("service unavailable"))
+			},
+			removed: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &WorkloadPolicyResource{client: &ClientSet{
+				TeamId:               "team-1",
+				RecommendationClient: &stubRecommendationClient{getWorkloadRecommendationPolicy: tt.getWorkloadRecommendationPolicy},
+			}}
+			req, resp := newTestReadRequest(t, r, "policy-1")
+			r.Read(context.Background(), req, resp)
+			if tt.removed {
+				assertRemovedFromState(t, resp)
+			} else {
+				assertKeptInState(t, resp)
+			}
+		})
+	}
+}
+
+func TestAccWorkloadPolicyResource(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_workload_policy", func(id string) bool {
+			return server.WorkloadPolicy(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWorkloadPolicyResourceConfig(server, "acc-policy", 0.75),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("devzero_workload_policy.test", "id"),
+					tfresource.TestCheckResourceAttr("devzero_workload_policy.test", "name", "acc-policy"),
+					tfresource.TestCheckResourceAttr("devzero_workload_policy.test", "team_id", testAccTeamID),
+					tfresource.TestCheckResourceAttr("devzero_workload_policy.test", "action_triggers.#", "2"),
+					tfresource.TestCheckResourceAttr("devzero_workload_policy.test", "cpu_vertical_scaling.target_percentile", "0.75"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "devzero_workload_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState by name testing
+			{
+				ResourceName:      "devzero_workload_policy.test",
+				ImportState:       true,
+				ImportStateId:     "name:acc-policy",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccWorkloadPolicyResourceConfig(server, "acc-policy-updated", 0.5),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_workload_policy.test", "name", "acc-policy-updated"),
+					tfresource.TestCheckResourceAttr("devzero_workload_policy.test", "cpu_vertical_scaling.target_percentile", "0.5"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccWorkloadPolicyResourceConfig(server *fakeapi.Server, name string, targetPercentile float64) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "devzero_workload_policy" "test" {
+  name               = %q
+  description        = "Acceptance test policy"
+  action_triggers    = ["on_detection", "on_schedule"]
+  cron_schedule      = "*/15 * * * *"
+  detection_triggers = ["pod_creation", "pod_update"]
+
+  cpu_vertical_scaling = {
+    enabled           = true
+    target_percentile = %g
+    min_request       = 25
+  }
+}
+`, name, targetPercentile)
+}
+
+func TestWorkloadPolicyResourceLookupIDByName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeapi.NewServer(t)
+	r := &WorkloadPolicyResource{client: &ClientSet{
+		TeamId:               "team-1",
+		RecommendationClient: apiv1connect.NewK8SRecommendationServiceClient(http.DefaultClient, server.URL),
+	}}
+
+	policyIDs := map[string]string{}
+	for _, policy := range []struct{ team, name string }{
+		{"team-1", "policy"},
+		{"team-2", "policy"},
+		{"team-1", "twin"},
+		{"team-1", "twin"},
+	} {
+		createResp, err := r.client.RecommendationClient.CreateWorkloadRecommendationPolicy(ctx, connect.NewRequest(&apiv1.CreateWorkloadRecommendationPolicyRequest{
+			TeamId: policy.team,
+			Policy: &apiv1.WorkloadRecommendationPolicy{Name: policy.name},
+		}))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		policyIDs[policy.team+"/"+policy.name] = createResp.Msg.Policy.PolicyId
+	}
+
+	tests := map[string]struct {
+		teamID      string
+		name        string
+		expectedID  string
+		expectError string
+	}{
+		"found":          {teamID: "team-1", name: "policy", expectedID: policyIDs["team-1/policy"]},
+		"other team":     {teamID: "team-2", name: "policy", expectedID: policyIDs["team-2/policy"]},
+		"not found":      {teamID: "team-2", name: "twin", expectError: `no workload policy named "twin" found in team "team-2"`},
+		"ambiguous name": {teamID: "team-1", name: "twin", expectError: `more than one workload policy is named "twin"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := r.lookupIDByName(ctx, tt.teamID, tt.name)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if id != tt.expectedID {
+				t.Errorf("Expected id %q, got %q", tt.expectedID, id)
+			}
+		})
+	}
+}
@@ -3,8 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"connectrpc.com/connect"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -36,6 +39,7 @@ type WorkloadRuleResource struct {
 
 type WorkloadRuleResourceModel struct {
 	Id                        types.String             `tfsdk:"id"`
+	TeamId                    types.String             `tfsdk:"team_id"`
 	ClusterId                 types.String             `tfsdk:"cluster_id"`
 	Namespace                 types.String             `tfsdk:"namespace"`
 	Kind                      types.String             `tfsdk:"kind"`
@@ -56,6 +60,8 @@ type WorkloadRuleResourceModel struct {
 	LiveMigrationEnabled      types.Bool               `tfsdk:"live_migration_enabled"`
 	UseInPlaceVerticalScaling types.Bool               `tfsdk:"use_in_place_vertical_scaling"`
 	Containers                []ContainerRuleModel     `tfsdk:"containers"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type ResourceRuleConfigModel struct {
@@ -286,6 +292,7 @@ func (r *WorkloadRuleResource) Schema(ctx context.Context, req resource.SchemaRe
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"team_id": teamIDAttribute(),
 			"cluster_id": schema.StringAttribute{
 				Description: "ID of the cluster this rule targets",
 				Required:    true,
@@ -569,6 +576,10 @@ func (r *WorkloadRuleResource) Schema(ctx context.Context, req resource.SchemaRe
 				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -597,7 +608,19 @@ func (r *WorkloadRuleResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	upsertReq := data.toProto(ctx, &resp.Diagnostics, r.client.TeamId)
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "create", createTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
+	upsertReq := data.toProto(ctx, &resp.Diagnostics, teamId)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -629,22 +652,41 @@ func (r *WorkloadRuleResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "read", readTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
 	getRuleResp, err := r.client.RecommendationClient.GetWorkloadRuleByID(ctx, connect.NewRequest(&apiv1.GetWorkloadRuleByIDRequest{
-		TeamId: r.client.TeamId,
+		TeamId: teamId,
 		RuleId: data.Id.ValueString(),
 	}))
 	if err != nil {
+		if isNotFound(err) {
+			removeMissingResource(ctx, &resp.State, "Workload rule", data.Id.ValueString())
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get workload rule, got error: %s", err))
 		return
 	}
 	if getRuleResp.Msg.Rule == nil {
-		resp.Diagnostics.AddError("Client Error", "Workload rule not found")
+		removeMissingResource(ctx, &resp.State, "Workload rule", data.Id.ValueString())
 		return
 	}
 
 	prior := data
 	data.fromProto(getRuleResp.Msg.Rule)
-	data.preserveNullsFrom(&prior)
+	// An imported rule has no prior configuration to preserve nulls from.
+	if !prior.ClusterId.IsNull() {
+		data.preserveNullsFrom(&prior)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -657,7 +699,19 @@ func (r *WorkloadRuleResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	upsertReq := data.toProto(ctx, &resp.Diagnostics, r.client.TeamId)
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "update", updateTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+	data.TeamId = types.StringValue(teamId)
+
+	upsertReq := data.toProto(ctx, &resp.Diagnostics, teamId)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -687,8 +741,19 @@ func (r *WorkloadRuleResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withTimeout(ctx, &resp.Diagnostics, "delete", deleteTimeout)
+	defer done()
+
+	teamId := r.client.teamID(data.TeamId)
+
 	_, err := r.client.RecommendationClient.DeleteWorkloadRule(ctx, connect.NewRequest(&apiv1.DeleteWorkloadRuleRequest{
-		TeamId: r.client.TeamId,
+		TeamId: teamId,
 		RuleId: data.Id.ValueString(),
 	}))
 	if err != nil {
@@ -697,8 +762,52 @@ func (r *WorkloadRuleResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 }
 
+// ImportState imports a workload rule by ID, or by workload from
+// "<cluster_id>/<namespace>/<kind>/<name>" or
+// "<team_id>/<cluster_id>/<namespace>/<kind>/<name>".
 func (r *WorkloadRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parts := strings.Split(req.ID, "/")
+	if len(parts) <= 2 {
+		importStateWithTeamID(ctx, req, resp)
+		return
+	}
+
+	if (len(parts) != 4 && len(parts) != 5) || slices.Contains(parts, "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <rule_id>, <team_id>/<rule_id>, <cluster_id>/<namespace>/<kind>/<name> "+
+				"or <team_id>/<cluster_id>/<namespace>/<kind>/<name>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	teamId := ""
+	if len(parts) == 5 {
+		teamId, parts = parts[0], parts[1:]
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), teamId)...)
+	}
+	teamId = r.client.teamID(types.StringValue(teamId))
+
+	clusterId, namespace, kind, name := parts[0], parts[1], parts[2], parts[3]
+	getRuleReq := &apiv1.GetWorkloadRuleByWorkloadRequest{
+		TeamId:       teamId,
+		ClusterId:    clusterId,
+		Namespace:    namespace,
+		Kind:         kind,
+		WorkloadName: name,
+	}
+
+	getRuleResp, err := r.client.RecommendationClient.GetWorkloadRuleByWorkload(ctx, connect.NewRequest(getRuleReq))
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to import workload rule of %s %s/%s in cluster %s, got error: %s", kind, namespace, name, clusterId, err))
+		return
+	}
+	if !getRuleResp.Msg.Found || getRuleResp.Msg.Rule == nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("No workload rule found for %s %s/%s in cluster %s of team %q.", kind, namespace, name, clusterId, teamId))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), getRuleResp.Msg.Rule.RuleId)...)
 }
 
 // ---------- toProto / fromProto ----------
@@ -2,15 +2,25 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"connectrpc.com/connect"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 
+	"github.com/devzero-inc/terraform-provider-devzero/internal/fakeapi"
 	apiv1 "github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1"
+	"github.com/devzero-inc/terraform-provider-devzero/internal/gen/api/v1/apiv1connect"
 )
 
 func TestWorkloadRuleResourceSchema(t *testing.T) {
@@ -1665,3 +1675,264 @@ func TestWorkloadRuleResourceModel(t *testing.T) {
 		}
 	})
 }
+
+func TestWorkloadRuleResourceRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		getWorkloadRuleByID func(context.Context, *connect.Request[apiv1.GetWorkloadRuleByIDRequest]) (*connect.Response[apiv1.GetWorkloadRuleByIDResponse], error)
+		removed             bool
+	}{
+		"not found error": {
+			getWorkloadRuleByID: func(context.Context, *connect.Request[apiv1.GetWorkloadRuleByIDRequest]) (*connect.Response[apiv1.GetWorkloadRuleByIDResponse], error) {
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("rule not found"))
+			},
+			removed: true,
+		},
+		"nil rule": {
+			getWorkloadRuleByID: func(context.Context, *connect.Request[apiv1.GetWorkloadRuleByIDRequest]) (*connect.Response[apiv1.GetWorkloadRuleByIDResponse], error) {
+				return connect.NewResponse(&apiv1.GetWorkloadRuleByIDResponse{}), nil
+			},
+			removed: true,
+		},
+		"unavailable": {
+			getWorkloadRuleByID: func(context.Context, *connect.Request[apiv1.GetWorkloadRuleByIDRequest]) (*connect.Response[apiv1.GetWorkloadRuleByIDResponse], error) {
+				return nil, connect.NewError(connect.CodeUnavailable, errors.New("service unavailable"))
+			},
+			removed: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &WorkloadRuleResource{client: &ClientSet{
+				TeamId:               "team-1",
+				RecommendationClient: &stubRecommendationClient{getWorkloadRuleByID: tt.getWorkloadRuleByID},
+			}}
+			req, resp := newTestReadRequest(t, r, "rule-1")
+			r.Read(context.Background(), req, resp)
+			if tt.removed {
+				assertRemovedFromState(t, resp)
+			} else {
+				assertKeptInState(t, resp)
+			}
+		})
+	}
+}
+
+func TestWorkloadRuleResourceRead_PreserveNulls(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		// priorClusterID is left empty for import-shaped state, which only has the id set.
+		priorClusterID string
+		cpuRuleNull    bool
+	}{
+		"imported": {
+			cpuRuleNull: false,
+		},
+		"managed": {
+			priorClusterID: "cluster-abc",
+			cpuRuleNull:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &WorkloadRuleResource{client: &ClientSet{
+				TeamId: "team-1",
+				RecommendationClient: &stubRecommendationClient{
+					getWorkloadRuleByID: func(context.Context, *connect.Request[apiv1.GetWorkloadRuleByIDRequest]) (*connect.Response[apiv1.GetWorkloadRuleByIDResponse], error) {
+						return connect.NewResponse(&apiv1.GetWorkloadRuleByIDResponse{Rule: &apiv1.WorkloadRule{
+							RuleId:    "rule-1",
+							ClusterId: "cluster-abc",
+							Namespace: "default",
+							Kind:      "Deployment",
+							Name:      "api",
+							CpuRule:   &apiv1.ResourceRuleConfig{Enabled: true},
+						}}), nil
+					},
+				},
+			}}
+			req, resp := newTestReadRequest(t, r, "rule-1")
+			if tt.priorClusterID != "" {
+				if diags := req.State.SetAttribute(ctx, path.Root("cluster_id"), tt.priorClusterID); diags.HasError() {
+					t.Fatalf("Unable to set cluster_id in state: %v", diags)
+				}
+			}
+
+			r.Read(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read had errors: %v", resp.Diagnostics)
+			}
+
+			var data WorkloadRuleResourceModel
+			if diags := resp.State.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("Unable to get state: %v", diags)
+			}
+			if data.ClusterId.ValueString() != "cluster-abc" {
+				t.Errorf("Expected cluster_id to be read back, got %s", data.ClusterId)
+			}
+			if (data.CpuRule == nil) != tt.cpuRuleNull {
+				t.Errorf("Expected cpu_rule null to be %t, got %v", tt.cpuRuleNull, data.CpuRule)
+			}
+		})
+	}
+}
+
+func TestAccWorkloadRuleResource(t *testing.T) {
+	server := fakeapi.NewServer(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed("devzero_workload_rule", func(id string) bool {
+			return server.WorkloadRule(id) != nil
+		}),
+		Steps: []tfresource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWorkloadRuleResourceConfig(server, 0.75),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("devzero_workload_rule.test", "id"),
+					tfresource.TestCheckResourceAttrPair("devzero_workload_rule.test", "cluster_id", "devzero_cluster.test", "id"),
+					tfresource.TestCheckResourceAttr("devzero_workload_rule.test", "name", "my-api"),
+					tfresource.TestCheckResourceAttr("devzero_workload_rule.test", "auto_generate", "false"),
+					tfresource.TestCheckResourceAttr("devzero_workload_rule.test", "cpu_rule.target_percentile", "0.75"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "devzero_workload_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Unset lists are kept null in state but read back empty on import.
+				ImportStateVerifyIgnore: []string{"scheduler_plugins"},
+			},
+			// ImportState by workload testing
+			{
+				ResourceName: "devzero_workload_rule.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					cluster := s.RootModule().Resources["devzero_cluster.test"]
+					if cluster == nil {
+						return "", fmt.Errorf("devzero_cluster.test not found in state")
+					}
+					return cluster.Primary.ID + "/production/Deployment/my-api", nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"scheduler_plugins"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccWorkloadRuleResourceConfig(server, 0.5),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("devzero_workload_rule.test", "cpu_rule.target_percentile", "0.5"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccWorkloadRuleResourceConfig(server *fakeapi.Server, targetPercentile float64) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "devzero_cluster" "test" {
+  name = "acc-cluster"
+}
+
+resource "devzero_workload_rule" "test" {
+  cluster_id = devzero_cluster.test.id
+  namespace  = "production"
+  kind       = "Deployment"
+  name       = "my-api"
+
+  action_triggers    = ["on_detection"]
+  detection_triggers = ["pod_creation"]
+
+  cpu_rule = {
+    enabled           = true
+    min_request       = 10
+    max_request       = 32000
+    target_percentile = %g
+  }
+}
+`, targetPercentile)
+}
+
+func TestWorkloadRuleResourceImportState_Workload(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakeapi.NewServer(t)
+	client := &ClientSet{
+		TeamId:                "team-1",
+		ClusterMutationClient: apiv1connect.NewClusterMutationServiceClient(http.DefaultClient, server.URL),
+		RecommendationClient:  apiv1connect.NewK8SRecommendationServiceClient(http.DefaultClient, server.URL),
+	}
+
+	createClusterResp, err := client.ClusterMutationClient.CreateCluster(ctx, connect.NewRequest(&apiv1.CreateClusterRequest{TeamId: "team-1", ClusterName: "cluster"}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	clusterID := createClusterResp.Msg.Cluster.Id
+
+	upsertResp, err := client.RecommendationClient.UpsertManualWorkloadRule(ctx, connect.NewRequest(&apiv1.UpsertManualWorkloadRuleRequest{
+		TeamId:    "team-1",
+		ClusterId: clusterID,
+		Namespace: "production",
+		Kind:      "Deployment",
+		Name:      "my-api",
+	}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ruleID := upsertResp.Msg.Rule.RuleId
+
+	tests := map[string]struct {
+		importID       string
+		expectedID     string
+		expectedTeamID string
+		expectError    string
+	}{
+		"rule id":           {importID: "rule-1", expectedID: "rule-1"},
+		"workload":          {importID: clusterID + "/production/Deployment/my-api", expectedID: ruleID},
+		"team and workload": {importID: "team-1/" + clusterID + "/production/Deployment/my-api", expectedID: ruleID, expectedTeamID: "team-1"},
+		"unknown workload":  {importID: clusterID + "/production/Deployment/other", expectError: "No workload rule found for Deployment production/other"},
+		"unknown cluster":   {importID: "cluster-0/production/Deployment/my-api", expectError: "Unable to import workload rule"},
+		"empty namespace":   {importID: clusterID + "//Deployment/my-api", expectError: "Expected import identifier"},
+		"too few parts":     {importID: clusterID + "/production/Deployment", expectError: "Expected import identifier"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &WorkloadRuleResource{client: client}
+			resp := newTestImportStateResponse(t, r)
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.importID}, resp)
+
+			if tt.expectError != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected an error")
+				}
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.expectError) {
+					t.Errorf("Expected error detail to contain %q, got %q", tt.expectError, detail)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+
+			var id, teamID types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			resp.State.GetAttribute(ctx, path.Root("team_id"), &teamID)
+			if id.ValueString() != tt.expectedID {
+				t.Errorf("Expected id %q, got %q", tt.expectedID, id.ValueString())
+			}
+			if teamID.ValueString() != tt.expectedTeamID {
+				t.Errorf("Expected team_id %q, got %q", tt.expectedTeamID, teamID.ValueString())
+			}
+		})
+	}
+}